@@ -8,3 +8,8 @@ resource "mailjet_sender" "sender_example" {
 data "mailjet_dns" "example" {
   dns_id = resource.mailjet_sender.sender_example.dns_id
 }
+
+# The DNS settings can also be retrieved by domain name, e.g. from another workspace
+data "mailjet_dns" "example_by_domain" {
+  domain = "mailjet.example.com"
+}
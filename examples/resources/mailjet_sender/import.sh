@@ -1,2 +1,16 @@
 # Sender can be imported by specifying the numeric ID
 terraform import mailjet_sender.example 123
+
+# Sender can also be imported by specifying its email address or its domain pattern
+terraform import mailjet_sender.example my_email@mailjet.example.com
+terraform import mailjet_sender.example '*@mailjet.example.com'
+
+# The credentials block is not available during the import, the sender is looked up with the API keys of the provider.
+# To import a sender of a sub-account, use a provider configuration with the API keys of the sub-account:
+#   provider "mailjet" {
+#     alias           = "subaccount"
+#     api_key_public  = var.subaccount_api_key_public
+#     api_key_private = var.subaccount_api_key_private
+#   }
+# and set `provider = mailjet.subaccount` on the resource instead of a credentials block.
+terraform import mailjet_sender.subaccount 456
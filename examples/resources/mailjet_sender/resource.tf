@@ -11,3 +11,7 @@ resource "mailjet_sender" "sender_example_whole_domain" {
   is_default_sender = false
   email_type = "unknown"
 }
+
+resource "mailjet_sender" "sender_example_minimal" {
+  email = "*@other.mailjet.example.com"
+}
@@ -6,7 +6,21 @@ resource "mailjet_sender" "sender_example" {
 }
 
 # You can retrieve the DNS entries to set using the mailjet_dns data source
+data "mailjet_dns" "dns_example" {
+  dns_id = mailjet_sender.sender_example.dns_id
+}
 
 resource "mailjet_sender_validate" "sender_validate_example" {
     id = mailjet_sender.sender_example.id
+
+    # Validate the sender again when the expected DNS entries change
+    triggers = {
+      dkim = data.mailjet_dns.dns_example.entries[0].dkim_record_value
+      spf  = data.mailjet_dns.dns_example.entries[0].spf_record_value
+    }
+
+    # Keep trying to validate the sender while the DNS entries are propagated
+    timeouts {
+      create = "10m"
+    }
 }
\ No newline at end of file
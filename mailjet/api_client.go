@@ -0,0 +1,281 @@
+package mailjet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailjet/mailjet-apiv3-go/v3/resources"
+	"github.com/mailjet/mailjet-apiv3-go/v4"
+)
+
+const defaultRequestTimeout = time.Minute
+
+var errReadOnlyMode = errors.New("the provider is in read-only mode, no changes can be made to the Mailjet account")
+
+// apiClient is a typed layer in front of the Mailjet client.
+// Every call takes a context so it can be cancelled by Terraform and is bounded by the request timeout.
+type apiClient struct {
+	client         *mailjet.Client
+	baseURL        string
+	requestTimeout time.Duration
+	// readOnly rejects any mutating call, as a safety net for the resources checking it themselves
+	readOnly bool
+}
+
+func newAPIClient(client *mailjet.Client, baseURL string, requestTimeout time.Duration, readOnly bool) *apiClient {
+	return &apiClient{
+		client:         client,
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		requestTimeout: requestTimeout,
+		readOnly:       readOnly,
+	}
+}
+
+func (c *apiClient) GetMyProfile(ctx context.Context) ([]resources.Myprofile, error) {
+	var responseData []resources.Myprofile
+	err := c.get(ctx, &mailjet.Request{Resource: "myprofile"}, &responseData)
+	return responseData, err
+}
+
+func (c *apiClient) GetSender(ctx context.Context, id int64) ([]resources.Sender, error) {
+	var responseData []resources.Sender
+	err := c.get(ctx, &mailjet.Request{Resource: "sender", ID: id}, &responseData)
+	return responseData, err
+}
+
+// FindSender retrieves a sender by its ID, nil is returned when the sender does not exist
+func (c *apiClient) FindSender(ctx context.Context, id int64) (*resources.Sender, error) {
+	responseData, err := c.GetSender(ctx, id)
+	if isNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(responseData) == 0 {
+		return nil, nil
+	}
+	if len(responseData) != 1 {
+		return nil, fmt.Errorf("expected 1 response entry, got %d", len(responseData))
+	}
+
+	return &responseData[0], nil
+}
+
+// FindSendersByEmail looks up senders by email address, *@example.com can be used for a whole domain
+func (c *apiClient) FindSendersByEmail(ctx context.Context, email string) ([]resources.Sender, error) {
+	var responseData []resources.Sender
+	err := c.get(ctx, &mailjet.Request{Resource: "sender", AltID: email}, &responseData)
+	return responseData, err
+}
+
+func (c *apiClient) CreateSender(ctx context.Context, sender *resources.Sender) ([]resources.Sender, error) {
+	var responseData []resources.Sender
+	err := c.post(ctx, &mailjet.FullRequest{Info: &mailjet.Request{Resource: "sender"}, Payload: sender}, &responseData)
+	return responseData, err
+}
+
+// UpdateSender changes the editable information of the sender with the given ID, the email address cannot be changed
+func (c *apiClient) UpdateSender(ctx context.Context, id int64, sender *resources.Sender) error {
+	mailjetFullRequest := &mailjet.FullRequest{
+		Info: &mailjet.Request{
+			Resource: "sender",
+			ID:       id,
+		},
+		Payload: resources.Sender{
+			Name:            sender.Name,
+			EmailType:       sender.EmailType,
+			IsDefaultSender: sender.IsDefaultSender,
+		},
+	}
+
+	return c.put(ctx, mailjetFullRequest, []string{"Name", "EmailType", "IsDefaultSender"})
+}
+
+func (c *apiClient) DeleteSender(ctx context.Context, id int64) error {
+	return c.delete(ctx, &mailjet.Request{Resource: "sender", ID: id})
+}
+
+// ValidateSender asks Mailjet to check the sender, the result is returned whether it has been validated or not
+func (c *apiClient) ValidateSender(ctx context.Context, id int64) (*resources.SenderValidate, error) {
+	body, err := c.send(ctx, http.MethodPost, &mailjet.Request{Resource: "sender", ID: id, Action: "validate"})
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeSenderValidation(body)
+}
+
+// decodeSenderValidation accepts the validation result wrapped in the usual Data list or given directly,
+// the Mailjet API has used both forms for this action
+func decodeSenderValidation(body []byte) (*resources.SenderValidate, error) {
+	var wrapped struct {
+		Data []resources.SenderValidate
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return nil, fmt.Errorf("unexpected validation response: %w", err)
+	}
+
+	if wrapped.Data != nil {
+		if len(wrapped.Data) != 1 {
+			return nil, fmt.Errorf("expected 1 validation response entry, got %d", len(wrapped.Data))
+		}
+		return &wrapped.Data[0], nil
+	}
+
+	var validation resources.SenderValidate
+	if err := json.Unmarshal(body, &validation); err != nil {
+		return nil, fmt.Errorf("unexpected validation response: %w", err)
+	}
+
+	return &validation, nil
+}
+
+func (c *apiClient) GetDNS(ctx context.Context, id int64) ([]resources.Dns, error) {
+	var responseData []resources.Dns
+	err := c.get(ctx, &mailjet.Request{Resource: "dns", ID: id}, &responseData)
+	return responseData, err
+}
+
+// GetDNSByDomain retrieves the DNS settings of a domain by its name, e.g. example.com
+func (c *apiClient) GetDNSByDomain(ctx context.Context, domain string) ([]resources.Dns, error) {
+	var responseData []resources.Dns
+	err := c.get(ctx, &mailjet.Request{Resource: "dns", AltID: domain}, &responseData)
+	return responseData, err
+}
+
+func (c *apiClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = newAPILogContext(ctx, c.client.APIKeyPrivate())
+
+	if c.requestTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.requestTimeout)
+}
+
+func (c *apiClient) get(ctx context.Context, mr *mailjet.Request, resp any) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	return interruptionError(ctx, c.client.Get(mr, resp, mailjet.WithContext(ctx)))
+}
+
+func (c *apiClient) post(ctx context.Context, fmr *mailjet.FullRequest, resp any) error {
+	if c.readOnly {
+		return errReadOnlyMode
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	return interruptionError(ctx, c.client.Post(fmr, resp, mailjet.WithContext(ctx)))
+}
+
+func (c *apiClient) put(ctx context.Context, fmr *mailjet.FullRequest, onlyFields []string) error {
+	if c.readOnly {
+		return errReadOnlyMode
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	return interruptionError(ctx, c.client.Put(fmr, onlyFields, mailjet.WithContext(ctx)))
+}
+
+// delete is done without the Mailjet client since it does not accept request options for this method
+func (c *apiClient) delete(ctx context.Context, mr *mailjet.Request) error {
+	_, err := c.send(ctx, http.MethodDelete, mr)
+	return err
+}
+
+// send does a request without payload through the HTTP client of the Mailjet client and returns the raw response body.
+// It is used when the Mailjet client cannot do the call or decode its response.
+func (c *apiClient) send(ctx context.Context, method string, mr *mailjet.Request) ([]byte, error) {
+	if c.readOnly && method != http.MethodGet {
+		return nil, errReadOnlyMode
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.restURL(mr), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.client.APIKeyPublic(), c.client.APIKeyPrivate())
+
+	resp, err := c.client.Client().Do(req)
+	if err != nil {
+		return nil, interruptionError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponseError(resp); err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, interruptionError(ctx, err)
+	}
+
+	return body, nil
+}
+
+func isNotFoundError(err error) bool {
+	var requestError mailjet.RequestError
+	return errors.As(err, &requestError) && requestError.StatusCode == http.StatusNotFound
+}
+
+// interruptionError keeps the cancellation cause visible since the Mailjet client does not wrap the errors it returns
+func interruptionError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("request to the Mailjet API interrupted: %w", ctx.Err())
+	}
+
+	return err
+}
+
+func (c *apiClient) restURL(mr *mailjet.Request) string {
+	tokens := []string{c.baseURL, "REST", mr.Resource}
+	if mr.ID != 0 {
+		tokens = append(tokens, strconv.FormatInt(mr.ID, 10))
+	} else if mr.AltID != "" {
+		tokens = append(tokens, mr.AltID)
+	}
+	if mr.Action != "" {
+		tokens = append(tokens, mr.Action)
+	}
+
+	return strings.Join(tokens, "/")
+}
+
+func checkResponseError(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 400 {
+		return nil
+	}
+
+	requestError := mailjet.RequestError{StatusCode: resp.StatusCode}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		requestError.ErrorMessage = "unable to read response body"
+		requestError.ErrorInfo = err.Error()
+		return requestError
+	}
+
+	if err := json.Unmarshal(body, &requestError); err != nil {
+		requestError.ErrorMessage = "unexpected server response: " + string(body)
+	}
+	requestError.StatusCode = resp.StatusCode
+
+	return requestError
+}
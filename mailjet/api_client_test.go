@@ -0,0 +1,283 @@
+package mailjet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mailjet/mailjet-apiv3-go/v3/resources"
+	"github.com/mailjet/mailjet-apiv3-go/v4"
+)
+
+func newTestAPIClient(t *testing.T, handler http.HandlerFunc, requestTimeout time.Duration) *apiClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return newAPIClient(mailjet.NewMailjetClient("public", "private", server.URL+"/v3"), server.URL+"/v3", requestTimeout, false)
+}
+
+func TestAPIClientDeleteSender(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		statusCode         int
+		expectedStatusCode int
+	}
+
+	tests := map[string]testCase{
+		"deleted": {
+			statusCode: http.StatusNoContent,
+		},
+		"not_found": {
+			statusCode:         http.StatusNotFound,
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/v3/REST/sender/12" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				if user, password, _ := r.BasicAuth(); user != "public" || password != "private" {
+					t.Errorf("unexpected credentials: %s:%s", user, password)
+				}
+				w.WriteHeader(test.statusCode)
+			}, time.Minute)
+
+			err := client.DeleteSender(context.Background(), 12)
+
+			var requestError mailjet.RequestError
+			statusCode := 0
+			if errors.As(err, &requestError) {
+				statusCode = requestError.StatusCode
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(statusCode, test.expectedStatusCode); diff != "" {
+				t.Errorf("unexpected status code difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIClientRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	client := newTestAPIClient(t, func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+	}, 50*time.Millisecond)
+
+	_, err := client.GetSender(context.Background(), 12)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to be interrupted by its timeout, got: %v", err)
+	}
+}
+
+func TestAPIClientFindSender(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		statusCode     int
+		body           string
+		expectedSender *resources.Sender
+		expectedError  bool
+	}
+
+	tests := map[string]testCase{
+		"found": {
+			statusCode:     http.StatusOK,
+			body:           `{"Count":1,"Data":[{"ID":12,"Email":"*@example.com","Status":"Active"}],"Total":1}`,
+			expectedSender: &resources.Sender{ID: 12, Email: "*@example.com", Status: "Active"},
+		},
+		"not_found": {
+			statusCode: http.StatusNotFound,
+			body:       `{"ErrorInfo":"","ErrorMessage":"Object not found","StatusCode":404}`,
+		},
+		"empty_response": {
+			statusCode: http.StatusOK,
+			body:       `{"Count":0,"Data":[],"Total":0}`,
+		},
+		"multiple_entries": {
+			statusCode:    http.StatusOK,
+			body:          `{"Count":2,"Data":[{"ID":12},{"ID":13}],"Total":2}`,
+			expectedError: true,
+		},
+		"server_error": {
+			statusCode:    http.StatusBadRequest,
+			body:          `{"ErrorInfo":"","ErrorMessage":"Invalid request","StatusCode":400}`,
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.statusCode)
+				_, _ = w.Write([]byte(test.body))
+			}, time.Minute)
+
+			sender, err := client.FindSender(context.Background(), 12)
+
+			if diff := cmp.Diff(err != nil, test.expectedError); diff != "" {
+				t.Errorf("unexpected error difference: %s (%v)", diff, err)
+			}
+			if diff := cmp.Diff(sender, test.expectedSender); diff != "" {
+				t.Errorf("unexpected sender difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIClientValidateSender(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		statusCode         int
+		body               string
+		expectedValidation *resources.SenderValidate
+		expectedError      bool
+	}
+
+	tests := map[string]testCase{
+		"wrapped": {
+			statusCode:         http.StatusOK,
+			body:               `{"Count":1,"Data":[{"Errors":{},"ValidationMethod":"ActivationEmail","GlobalError":""}],"Total":1}`,
+			expectedValidation: &resources.SenderValidate{Errors: map[string]string{}, ValidationMethod: "ActivationEmail"},
+		},
+		"direct": {
+			statusCode: http.StatusOK,
+			body:       `{"Errors":{"DNSValidationError":"No TXT record found"},"ValidationMethod":"","GlobalError":"Validation failed"}`,
+			expectedValidation: &resources.SenderValidate{
+				Errors:      map[string]string{"DNSValidationError": "No TXT record found"},
+				GlobalError: "Validation failed",
+			},
+		},
+		"empty_data": {
+			statusCode:    http.StatusOK,
+			body:          `{"Count":0,"Data":[],"Total":0}`,
+			expectedError: true,
+		},
+		"invalid_body": {
+			statusCode:    http.StatusOK,
+			body:          `validated`,
+			expectedError: true,
+		},
+		"server_error": {
+			statusCode:    http.StatusBadRequest,
+			body:          `{"ErrorInfo":"","ErrorMessage":"Sender already validated","StatusCode":400}`,
+			expectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/v3/REST/sender/12/validate" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.statusCode)
+				_, _ = w.Write([]byte(test.body))
+			}, time.Minute)
+
+			validation, err := client.ValidateSender(context.Background(), 12)
+
+			if diff := cmp.Diff(err != nil, test.expectedError); diff != "" {
+				t.Errorf("unexpected error difference: %s (%v)", diff, err)
+			}
+			if diff := cmp.Diff(validation, test.expectedValidation); diff != "" {
+				t.Errorf("unexpected validation difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIClientReadOnly(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		call func(client *apiClient) error
+	}
+
+	tests := map[string]testCase{
+		"create_sender": {
+			call: func(client *apiClient) error {
+				_, err := client.CreateSender(context.Background(), &resources.Sender{Email: "sender@example.com"})
+				return err
+			},
+		},
+		"update_sender": {
+			call: func(client *apiClient) error {
+				return client.UpdateSender(context.Background(), 12, &resources.Sender{Name: "Sender"})
+			},
+		},
+		"delete_sender": {
+			call: func(client *apiClient) error {
+				return client.DeleteSender(context.Background(), 12)
+			},
+		},
+		"validate_sender": {
+			call: func(client *apiClient) error {
+				_, err := client.ValidateSender(context.Background(), 12)
+				return err
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request in read-only mode: %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusInternalServerError)
+			}, time.Minute)
+			client.readOnly = true
+
+			if err := test.call(client); !errors.Is(err, errReadOnlyMode) {
+				t.Errorf("expected the read-only mode error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIClientReadOnlyAllowsReads(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPIClient(t, (&fakeSenderAPI{t: t, sender: newFakeSender()}).ServeHTTP, time.Minute)
+	client.readOnly = true
+
+	sender, err := client.FindSender(context.Background(), 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if sender == nil || sender.ID != 12 {
+		t.Errorf("expected sender #12, got: %v", sender)
+	}
+}
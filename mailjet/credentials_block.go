@@ -0,0 +1,53 @@
+package mailjet
+
+import (
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	credentialsBlockDescription = "API keys overriding the ones of the provider for this element, e.g. to manage a sub-account. " +
+		"The retry, logging and rate limiting settings of the provider still apply."
+	credentialsPublicKeyDescription  = "Public API key for Mailjet."
+	credentialsPrivateKeyDescription = "Private API key for Mailjet."
+)
+
+type credentialsModel struct {
+	PublicKey  types.String `tfsdk:"public_key"`
+	PrivateKey types.String `tfsdk:"private_key"`
+}
+
+func credentialsResourceBlock() resourceschema.Block {
+	return resourceschema.SingleNestedBlock{
+		Description: credentialsBlockDescription,
+		Attributes: map[string]resourceschema.Attribute{
+			"public_key": resourceschema.StringAttribute{
+				Required:    true,
+				Description: credentialsPublicKeyDescription,
+			},
+			"private_key": resourceschema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: credentialsPrivateKeyDescription,
+			},
+		},
+	}
+}
+
+func credentialsDataSourceBlock() datasourceschema.Block {
+	return datasourceschema.SingleNestedBlock{
+		Description: credentialsBlockDescription,
+		Attributes: map[string]datasourceschema.Attribute{
+			"public_key": datasourceschema.StringAttribute{
+				Required:    true,
+				Description: credentialsPublicKeyDescription,
+			},
+			"private_key": datasourceschema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: credentialsPrivateKeyDescription,
+			},
+		},
+	}
+}
@@ -0,0 +1,135 @@
+package mailjet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const defaultCredentialsProfileName = "default"
+
+// credentialsProfile is a named set of settings read from the shared credentials file
+type credentialsProfile struct {
+	APIKeyPublic  string
+	APIKeyPrivate string
+	BaseURL       string
+	Region        string
+}
+
+func defaultSharedCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".mailjet", "credentials"), nil
+}
+
+func readSharedCredentialsFile(path string) (map[string]credentialsProfile, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	profiles, err := parseSharedCredentials(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return profiles, nil
+}
+
+// parseSharedCredentials reads INI-like content, values can be quoted so simple TOML files are also accepted:
+//
+//	[default]
+//	api_key_public = "..."
+//	api_key_private = "..."
+//	region = "us"
+func parseSharedCredentials(r io.Reader) (map[string]credentialsProfile, error) {
+	profiles := map[string]credentialsProfile{}
+	currentProfile := ""
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid profile header %q", lineNumber, line)
+			}
+			currentProfile = strings.TrimSpace(strings.Trim(line, "[]"))
+			if currentProfile == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+			profiles[currentProfile] = profiles[currentProfile]
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected a key = value pair", lineNumber)
+		}
+		if currentProfile == "" {
+			return nil, fmt.Errorf("line %d: setting defined outside of a profile", lineNumber)
+		}
+
+		key = strings.TrimSpace(key)
+		value, err := unquoteSharedCredentialsValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		profile := profiles[currentProfile]
+		switch key {
+		case "api_key_public":
+			profile.APIKeyPublic = value
+		case "api_key_private":
+			profile.APIKeyPrivate = value
+		case "base_url":
+			profile.BaseURL = value
+		case "region":
+			if _, ok := regionEndpoints[value]; !ok {
+				return nil, fmt.Errorf("line %d: unknown region %q, expected %q or %q", lineNumber, value, regionEU, regionUS)
+			}
+			profile.Region = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown setting %q", lineNumber, key)
+		}
+		if profile.BaseURL != "" && profile.Region != "" {
+			return nil, fmt.Errorf("line %d: base_url and region cannot be both set in profile %q", lineNumber, currentProfile)
+		}
+		profiles[currentProfile] = profile
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+func unquoteSharedCredentialsValue(value string) (string, error) {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return strconv.Unquote(value)
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1], nil
+	}
+	if strings.ContainsAny(value, `"'`) {
+		return "", errors.New("unbalanced quotes in value")
+	}
+
+	return value, nil
+}
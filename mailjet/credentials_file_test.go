@@ -0,0 +1,97 @@
+package mailjet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSharedCredentials(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		content          string
+		expectedProfiles map[string]credentialsProfile
+		expectedError    string
+	}
+
+	tests := map[string]testCase{
+		"empty": {
+			content:          "",
+			expectedProfiles: map[string]credentialsProfile{},
+		},
+		"ini": {
+			content: `
+# Main account
+[default]
+api_key_public = public
+api_key_private = private
+
+[us-account]
+api_key_public = us-public
+api_key_private = us-private
+region = us
+`,
+			expectedProfiles: map[string]credentialsProfile{
+				"default":    {APIKeyPublic: "public", APIKeyPrivate: "private"},
+				"us-account": {APIKeyPublic: "us-public", APIKeyPrivate: "us-private", Region: "us"},
+			},
+		},
+		"toml": {
+			content: `
+[staging]
+api_key_public = "public"
+api_key_private = 'private'
+base_url = "https://mailjet.example.com/v3"
+`,
+			expectedProfiles: map[string]credentialsProfile{
+				"staging": {APIKeyPublic: "public", APIKeyPrivate: "private", BaseURL: "https://mailjet.example.com/v3"},
+			},
+		},
+		"setting_outside_profile": {
+			content:       "api_key_public = public",
+			expectedError: "line 1: setting defined outside of a profile",
+		},
+		"unknown_setting": {
+			content:       "[default]\napi_key = public",
+			expectedError: `line 2: unknown setting "api_key"`,
+		},
+		"unknown_region": {
+			content:       "[default]\nregion = asia",
+			expectedError: `line 2: unknown region "asia", expected "eu" or "us"`,
+		},
+		"base_url_and_region": {
+			content:       "[default]\nregion = us\nbase_url = https://mailjet.example.com/v3",
+			expectedError: `line 3: base_url and region cannot be both set in profile "default"`,
+		},
+		"unbalanced_quotes": {
+			content:       "[default]\napi_key_public = \"public",
+			expectedError: "line 2: unbalanced quotes in value",
+		},
+		"invalid_header": {
+			content:       "[default",
+			expectedError: `line 1: invalid profile header "[default"`,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			profiles, err := parseSharedCredentials(strings.NewReader(test.content))
+
+			errorMessage := ""
+			if err != nil {
+				errorMessage = err.Error()
+			}
+			if diff := cmp.Diff(errorMessage, test.expectedError); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+			if diff := cmp.Diff(profiles, test.expectedProfiles); diff != "" {
+				t.Errorf("unexpected profiles difference: %s", diff)
+			}
+		})
+	}
+}
@@ -2,18 +2,18 @@ package mailjet
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mailjet/mailjet-apiv3-go/v3/resources"
-	"github.com/mailjet/mailjet-apiv3-go/v4"
 )
 
 var (
-	_ datasource.DataSource              = &dnsDataSource{}
-	_ datasource.DataSourceWithConfigure = &dnsDataSource{}
+	_ datasource.DataSource                     = &dnsDataSource{}
+	_ datasource.DataSourceWithConfigure        = &dnsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &dnsDataSource{}
 )
 
 func NewDNSDataSource() datasource.DataSource {
@@ -21,37 +21,35 @@ func NewDNSDataSource() datasource.DataSource {
 }
 
 type dnsDataSource struct {
-	client *mailjet.Client
+	providerData *providerData
 }
 
 func (d *dnsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
-	}
-
-	client, ok := req.ProviderData.(*mailjet.Client)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *mailjet.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-
-		return
-	}
-
-	d.client = client
+	d.providerData = configureProviderData(req.ProviderData, &resp.Diagnostics)
 }
 
 func (d *dnsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_dns"
 }
 
+func (d *dnsDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		ExactlyOneOf("dns_id", "domain"),
+	}
+}
+
 func (d *dnsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"dns_id": schema.Int64Attribute{
-				Required:    true,
-				Description: "Unique numeric ID of the domain settings",
+				Optional:    true,
+				Computed:    true,
+				Description: "Unique numeric ID of the domain settings. Exactly one of dns_id or domain must be set.",
+			},
+			"domain": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the domain, e.g. example.com. Exactly one of dns_id or domain must be set.",
 			},
 			"entries": schema.ListNestedAttribute{
 				Computed: true,
@@ -88,12 +86,18 @@ func (d *dnsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"credentials": credentialsDataSourceBlock(),
+		},
 	}
 }
 
 type dnsDataSourceModel struct {
-	DNSID   types.Int64 `tfsdk:"dns_id"`
-	Entries []dnsModel  `tfsdk:"entries"`
+	DNSID   types.Int64  `tfsdk:"dns_id"`
+	Domain  types.String `tfsdk:"domain"`
+	Entries []dnsModel   `tfsdk:"entries"`
+
+	Credentials *credentialsModel `tfsdk:"credentials"`
 }
 
 type dnsModel struct {
@@ -114,12 +118,18 @@ func (d *dnsDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
+	client := d.providerData.clientFor(state.Credentials)
+
 	var responseData []resources.Dns
-	mailjetRequest := &mailjet.Request{
-		Resource: "dns",
-		ID:       state.DNSID.ValueInt64(),
+	var err error
+	if !state.Domain.IsNull() {
+		responseData, err = client.GetDNSByDomain(ctx, state.Domain.ValueString())
+		if isNotFoundError(err) {
+			err = nil
+		}
+	} else {
+		responseData, err = client.GetDNS(ctx, state.DNSID.ValueInt64())
 	}
-	err := d.client.Get(mailjetRequest, &responseData)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read Mailjet DNS information",
@@ -128,6 +138,20 @@ func (d *dnsDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
+	if !state.Domain.IsNull() && len(responseData) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Unable to read Mailjet DNS information",
+			"No DNS settings found for the domain "+state.Domain.ValueString(),
+		)
+		return
+	}
+
+	if len(responseData) > 0 {
+		state.DNSID = types.Int64Value(responseData[0].ID)
+		state.Domain = types.StringValue(responseData[0].Domain)
+	}
+
 	for _, dnsEntry := range responseData {
 		dnsEntryState := dnsModel{
 			ID:                       types.Int64Value(dnsEntry.ID),
@@ -0,0 +1,102 @@
+package mailjet
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDNSDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		config        dnsDataSourceModel
+		expectedState *dnsDataSourceModel
+	}
+
+	expectedState := dnsDataSourceModel{
+		DNSID:  types.Int64Value(34),
+		Domain: types.StringValue("example.com"),
+		Entries: []dnsModel{{
+			ID:                       types.Int64Value(34),
+			Domain:                   types.StringValue("example.com"),
+			OwnerShipTokenRecordName: types.StringValue("mailjet._a1b2c3.example.com."),
+			OwnerShipToken:           types.StringValue("a1b2c3"),
+			SPFRecordValue:           types.StringValue("v=spf1 include:spf.mailjet.com ?all"),
+			DKIMRecordName:           types.StringValue("mailjet._domainkey.example.com."),
+			DKIMRecordValue:          types.StringValue("k=rsa; p=ABC"),
+		}},
+	}
+
+	tests := map[string]testCase{
+		"by_dns_id": {
+			config:        dnsDataSourceModel{DNSID: types.Int64Value(34), Domain: types.StringNull()},
+			expectedState: &expectedState,
+		},
+		"by_domain": {
+			config:        dnsDataSourceModel{DNSID: types.Int64Null(), Domain: types.StringValue("example.com")},
+			expectedState: &expectedState,
+		},
+		"unknown_domain": {
+			config: dnsDataSourceModel{DNSID: types.Int64Null(), Domain: types.StringValue("unknown.example.com")},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/v3/REST/dns/34", "/v3/REST/dns/example.com":
+					_, _ = w.Write([]byte(`{"Count":1,"Data":[{
+						"ID":34,
+						"Domain":"example.com",
+						"OwnerShipTokenRecordName":"mailjet._a1b2c3.example.com.",
+						"OwnerShipToken":"a1b2c3",
+						"SPFRecordValue":"v=spf1 include:spf.mailjet.com ?all",
+						"DKIMRecordName":"mailjet._domainkey.example.com.",
+						"DKIMRecordValue":"k=rsa; p=ABC"
+					}],"Total":1}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"ErrorInfo":"","ErrorMessage":"Object not found","StatusCode":404}`))
+				}
+			}, time.Minute)
+			d := &dnsDataSource{providerData: &providerData{client: client}}
+
+			var schemaResponse datasource.SchemaResponse
+			d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResponse)
+
+			// The configuration is built through a state since tfsdk.Config cannot be set from a model
+			config := tfsdk.State{Schema: schemaResponse.Schema}
+			if diags := config.Set(context.Background(), test.config); diags.HasError() {
+				t.Fatalf("unable to build the configuration: %v", diags)
+			}
+			req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResponse.Schema, Raw: config.Raw}}
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResponse.Schema}}
+			d.Read(context.Background(), req, &resp)
+
+			if diff := cmp.Diff(resp.Diagnostics.HasError(), test.expectedState == nil); diff != "" {
+				t.Fatalf("unexpected error difference: %s (%v)", diff, resp.Diagnostics)
+			}
+			if test.expectedState == nil {
+				return
+			}
+
+			var state dnsDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+			if diff := cmp.Diff(state, *test.expectedState); diff != "" {
+				t.Errorf("unexpected state difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package mailjet
+
+import "strings"
+
+const (
+	regionEU = "eu"
+	regionUS = "us"
+)
+
+// mailjetEndpoints holds the base URLs of the different versions of the Mailjet API
+type mailjetEndpoints struct {
+	V3  string
+	V31 string
+	V4  string
+}
+
+var regionEndpoints = map[string]mailjetEndpoints{
+	regionEU: {
+		V3:  "https://api.mailjet.com/v3",
+		V31: "https://api.mailjet.com/v3.1",
+		V4:  "https://api.mailjet.com/v4",
+	},
+	regionUS: {
+		V3:  "https://api.us.mailjet.com/v3",
+		V31: "https://api.us.mailjet.com/v3.1",
+		V4:  "https://api.us.mailjet.com/v4",
+	},
+}
+
+// endpointsFromBaseURL derives the endpoints of the other API versions from a custom v3 base URL.
+// They can only be derived when the base URL ends with /v3, they are left empty otherwise.
+func endpointsFromBaseURL(baseURL string) mailjetEndpoints {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	root, ok := strings.CutSuffix(baseURL, "/v3")
+	if !ok {
+		return mailjetEndpoints{V3: baseURL}
+	}
+
+	return mailjetEndpoints{
+		V3:  baseURL,
+		V31: baseURL + ".1",
+		V4:  root + "/v4",
+	}
+}
+
+// endpointsSource is one level of configuration able to select the Mailjet API endpoints
+type endpointsSource struct {
+	baseURL string
+	region  string
+}
+
+// resolveEndpoints uses the first source setting a base URL or a region, sources are given by order of precedence.
+// The returned region is empty when a custom base URL is used.
+func resolveEndpoints(sources ...endpointsSource) (string, mailjetEndpoints) {
+	for _, source := range sources {
+		if source.baseURL != "" {
+			return "", endpointsFromBaseURL(source.baseURL)
+		}
+		if endpoints, ok := regionEndpoints[source.region]; ok {
+			return source.region, endpoints
+		}
+	}
+
+	return regionEU, regionEndpoints[regionEU]
+}
@@ -0,0 +1,118 @@
+package mailjet
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEndpointsFromBaseURL(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		baseURL           string
+		expectedEndpoints mailjetEndpoints
+	}
+
+	tests := map[string]testCase{
+		"v3": {
+			baseURL: "https://mailjet.example.com/v3",
+			expectedEndpoints: mailjetEndpoints{
+				V3:  "https://mailjet.example.com/v3",
+				V31: "https://mailjet.example.com/v3.1",
+				V4:  "https://mailjet.example.com/v4",
+			},
+		},
+		"v3_trailing_slash": {
+			baseURL: "https://mailjet.example.com/v3/",
+			expectedEndpoints: mailjetEndpoints{
+				V3:  "https://mailjet.example.com/v3",
+				V31: "https://mailjet.example.com/v3.1",
+				V4:  "https://mailjet.example.com/v4",
+			},
+		},
+		"no_version": {
+			baseURL:           "http://localhost:8080",
+			expectedEndpoints: mailjetEndpoints{V3: "http://localhost:8080"},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(endpointsFromBaseURL(test.baseURL), test.expectedEndpoints); diff != "" {
+				t.Errorf("unexpected endpoints difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveEndpoints(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		sources           []endpointsSource
+		expectedRegion    string
+		expectedEndpoints mailjetEndpoints
+	}
+
+	tests := map[string]testCase{
+		"default": {
+			sources:           []endpointsSource{{}, {}},
+			expectedRegion:    regionEU,
+			expectedEndpoints: regionEndpoints[regionEU],
+		},
+		"region": {
+			sources:           []endpointsSource{{}, {region: regionUS}},
+			expectedRegion:    regionUS,
+			expectedEndpoints: regionEndpoints[regionUS],
+		},
+		"base_url": {
+			sources:           []endpointsSource{{}, {baseURL: "http://localhost:8080/v3"}},
+			expectedEndpoints: endpointsFromBaseURL("http://localhost:8080/v3"),
+		},
+		"first_base_url_wins": {
+			sources: []endpointsSource{
+				{baseURL: "http://first.example.com/v3"},
+				{baseURL: "http://second.example.com/v3"},
+			},
+			expectedEndpoints: endpointsFromBaseURL("http://first.example.com/v3"),
+		},
+		"first_region_wins": {
+			sources:           []endpointsSource{{region: regionUS}, {region: regionEU}},
+			expectedRegion:    regionUS,
+			expectedEndpoints: regionEndpoints[regionUS],
+		},
+		"region_before_base_url": {
+			sources:           []endpointsSource{{region: regionUS}, {baseURL: "http://localhost:8080/v3"}},
+			expectedRegion:    regionUS,
+			expectedEndpoints: regionEndpoints[regionUS],
+		},
+		"base_url_before_region": {
+			sources:           []endpointsSource{{baseURL: "http://localhost:8080/v3"}, {region: regionUS}},
+			expectedEndpoints: endpointsFromBaseURL("http://localhost:8080/v3"),
+		},
+		"unknown_region_skipped": {
+			sources:           []endpointsSource{{region: "ap"}, {region: regionUS}},
+			expectedRegion:    regionUS,
+			expectedEndpoints: regionEndpoints[regionUS],
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			region, endpoints := resolveEndpoints(test.sources...)
+			if region != test.expectedRegion {
+				t.Errorf("expected region %q, got %q", test.expectedRegion, region)
+			}
+			if diff := cmp.Diff(endpoints, test.expectedEndpoints); diff != "" {
+				t.Errorf("unexpected endpoints difference: %s", diff)
+			}
+		})
+	}
+}
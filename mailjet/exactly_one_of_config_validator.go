@@ -0,0 +1,68 @@
+package mailjet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+var _ datasource.ConfigValidator = exactlyOneOfConfigValidator{}
+
+type exactlyOneOfConfigValidator struct {
+	attributes []string
+}
+
+func (validator exactlyOneOfConfigValidator) Description(_ context.Context) string {
+	return `exactly one of these attributes must be set: ` + strings.Join(validator.attributes, ", ")
+}
+
+func (validator exactlyOneOfConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return validator.Description(ctx)
+}
+
+func (validator exactlyOneOfConfigValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	setAttributes := make([]string, 0, len(validator.attributes))
+
+	for _, attribute := range validator.attributes {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The decision is postponed to the apply when a value is not known yet
+		if value.IsUnknown() {
+			return
+		}
+		if !value.IsNull() {
+			setAttributes = append(setAttributes, attribute)
+		}
+	}
+
+	if len(setAttributes) == 1 {
+		return
+	}
+
+	if len(setAttributes) == 0 {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic(
+			"missing attribute",
+			fmt.Sprintf("none of the attributes are set, %s", validator.Description(ctx)),
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+		path.Root(setAttributes[1]),
+		"conflicting attributes",
+		fmt.Sprintf("%s are set together, %s", strings.Join(setAttributes, " and "), validator.Description(ctx)),
+	))
+}
+
+func ExactlyOneOf(attributes ...string) datasource.ConfigValidator {
+	return exactlyOneOfConfigValidator{attributes: attributes}
+}
@@ -0,0 +1,87 @@
+package mailjet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExactlyOneOf(t *testing.T) {
+	t.Parallel()
+
+	type configModel struct {
+		ID   types.Int64  `tfsdk:"id"`
+		Name types.String `tfsdk:"name"`
+	}
+
+	type testCase struct {
+		config              configModel
+		expectedDiagnostics diag.Diagnostics
+	}
+
+	tests := map[string]testCase{
+		"first": {
+			config: configModel{ID: types.Int64Value(12), Name: types.StringNull()},
+		},
+		"second": {
+			config: configModel{ID: types.Int64Null(), Name: types.StringValue("example.com")},
+		},
+		"unknown": {
+			config: configModel{ID: types.Int64Value(12), Name: types.StringUnknown()},
+		},
+		"none": {
+			config: configModel{ID: types.Int64Null(), Name: types.StringNull()},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"missing attribute",
+					"none of the attributes are set, exactly one of these attributes must be set: id, name",
+				),
+			},
+		},
+		"both": {
+			config: configModel{ID: types.Int64Value(12), Name: types.StringValue("example.com")},
+			expectedDiagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.Root("name"),
+					"conflicting attributes",
+					"id and name are set together, exactly one of these attributes must be set: id, name",
+				),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":   schema.Int64Attribute{Optional: true},
+					"name": schema.StringAttribute{Optional: true},
+				},
+			}
+			// The configuration is built through a state since tfsdk.Config cannot be set from a model
+			state := tfsdk.State{Schema: s}
+			if diags := state.Set(context.Background(), test.config); diags.HasError() {
+				t.Fatalf("unable to build the configuration: %v", diags)
+			}
+
+			request := datasource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}
+			response := datasource.ValidateConfigResponse{}
+
+			ExactlyOneOf("id", "name").ValidateDataSource(context.Background(), request, &response)
+
+			if diff := cmp.Diff(response.Diagnostics, test.expectedDiagnostics); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package mailjet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.Float64 = float64AtLeastValidator{}
+
+type float64AtLeastValidator struct {
+	minimum float64
+}
+
+func (validator float64AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("must be at least %g", validator.minimum)
+}
+
+func (validator float64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return validator.Description(ctx)
+}
+
+func (validator float64AtLeastValidator) ValidateFloat64(ctx context.Context, req validator.Float64Request, resp *validator.Float64Response) {
+	f := req.ConfigValue
+
+	if f.IsUnknown() || f.IsNull() {
+		return
+	}
+
+	if f.ValueFloat64() < validator.minimum {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"value too small",
+			fmt.Sprintf("%g %s", f.ValueFloat64(), validator.Description(ctx))),
+		)
+		return
+	}
+}
+
+func Float64AtLeast(minimum float64) validator.Float64 {
+	return float64AtLeastValidator{minimum: minimum}
+}
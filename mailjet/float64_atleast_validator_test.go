@@ -0,0 +1,66 @@
+package mailjet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFloat64AtLeast(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		val                 types.Float64
+		expectedDiagnostics diag.Diagnostics
+	}
+
+	tests := map[string]testCase{
+		"unknown": {
+			val: types.Float64Unknown(),
+		},
+		"null": {
+			val: types.Float64Null(),
+		},
+		"equal": {
+			val: types.Float64Value(1),
+		},
+		"greater": {
+			val: types.Float64Value(1.5),
+		},
+		"smaller": {
+			val: types.Float64Value(0.5),
+			expectedDiagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.Root("test"),
+					"value too small",
+					"0.5 must be at least 1",
+				),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			request := validator.Float64Request{
+				Path:           path.Root("test"),
+				PathExpression: path.MatchRoot("test"),
+				ConfigValue:    test.val,
+			}
+
+			response := validator.Float64Response{}
+
+			Float64AtLeast(1).ValidateFloat64(context.Background(), request, &response)
+
+			if diff := cmp.Diff(response.Diagnostics, test.expectedDiagnostics); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package mailjet
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// httpClientConfig holds the provider settings applied to the HTTP client shared by every Mailjet API call
+type httpClientConfig struct {
+	proxyURL              string
+	caCertPEM             []byte
+	insecureSkipVerify    bool
+	userAgent             string
+	maxRetries            int
+	retryMaxWait          time.Duration
+	maxRequestsPerSecond  float64
+	maxConcurrentRequests int
+}
+
+func newHTTPClient(config httpClientConfig) (*http.Client, error) {
+	transport, err := newBaseTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: newUserAgentTransport(
+			newRetryTransport(
+				newRateLimitTransport(newLoggingTransport(transport), config.maxRequestsPerSecond, config.maxConcurrentRequests),
+				config.maxRetries,
+				config.retryMaxWait,
+			),
+			config.userAgent,
+		),
+	}, nil
+}
+
+func newBaseTransport(config httpClientConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.proxyURL != "" {
+		proxyURL, err := url.Parse(config.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, errors.New("invalid proxy URL: a scheme and a host are expected, e.g. http://proxy.example.com:3128")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Only enabled on explicit request of the user, e.g. to debug a TLS intercepting proxy
+		InsecureSkipVerify: config.insecureSkipVerify, //nolint:gosec
+	}
+
+	if len(config.caCertPEM) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(config.caCertPEM) {
+			return nil, errors.New("no valid PEM encoded certificate found in the CA bundle")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
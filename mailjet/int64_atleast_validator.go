@@ -0,0 +1,44 @@
+package mailjet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.Int64 = int64AtLeastValidator{}
+
+type int64AtLeastValidator struct {
+	minimum int64
+}
+
+func (validator int64AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("must be at least %d", validator.minimum)
+}
+
+func (validator int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return validator.Description(ctx)
+}
+
+func (validator int64AtLeastValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	i := req.ConfigValue
+
+	if i.IsUnknown() || i.IsNull() {
+		return
+	}
+
+	if i.ValueInt64() < validator.minimum {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"value too small",
+			fmt.Sprintf("%d %s", i.ValueInt64(), validator.Description(ctx))),
+		)
+		return
+	}
+}
+
+func Int64AtLeast(minimum int64) validator.Int64 {
+	return int64AtLeastValidator{minimum: minimum}
+}
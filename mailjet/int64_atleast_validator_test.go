@@ -0,0 +1,66 @@
+package mailjet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInt64AtLeast(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		val                 types.Int64
+		expectedDiagnostics diag.Diagnostics
+	}
+
+	tests := map[string]testCase{
+		"unknown": {
+			val: types.Int64Unknown(),
+		},
+		"null": {
+			val: types.Int64Null(),
+		},
+		"equal": {
+			val: types.Int64Value(1),
+		},
+		"greater": {
+			val: types.Int64Value(2),
+		},
+		"smaller": {
+			val: types.Int64Value(0),
+			expectedDiagnostics: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.Root("test"),
+					"value too small",
+					"0 must be at least 1",
+				),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			request := validator.Int64Request{
+				Path:           path.Root("test"),
+				PathExpression: path.MatchRoot("test"),
+				ConfigValue:    test.val,
+			}
+
+			response := validator.Int64Response{}
+
+			Int64AtLeast(1).ValidateInt64(context.Background(), request, &response)
+
+			if diff := cmp.Diff(response.Diagnostics, test.expectedDiagnostics); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
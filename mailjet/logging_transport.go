@@ -0,0 +1,118 @@
+package mailjet
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiLogSubsystem is the tflog subsystem used to log the calls made to the Mailjet API.
+// Its level can be set independently with the TF_LOG_PROVIDER_MAILJET_API environment variable.
+const apiLogSubsystem = "mailjet_api"
+
+const redactedValue = "[REDACTED]"
+
+var _ http.RoundTripper = &loggingTransport{}
+
+// loggingTransport logs every request sent to the Mailjet API and the received response
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func newLoggingTransport(next http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		next: next,
+	}
+}
+
+// newAPILogContext sets up the subsystem logger, secret values are masked from every logged field
+func newAPILogContext(ctx context.Context, secrets ...string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, apiLogSubsystem, tflog.WithRootFields(), tflog.WithLevelFromEnv("TF_LOG_PROVIDER_MAILJET_API"))
+
+	nonEmptySecrets := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret != "" {
+			nonEmptySecrets = append(nonEmptySecrets, secret)
+		}
+	}
+
+	return tflog.SubsystemMaskAllFieldValuesStrings(ctx, apiLogSubsystem, nonEmptySecrets...)
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Sending request to the Mailjet API", fields)
+	tflog.SubsystemTrace(ctx, apiLogSubsystem, "Mailjet API request details", fields, map[string]interface{}{
+		"http_request_headers": redactHeaders(req.Header),
+		"http_request_body":    readRequestBody(req),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.SubsystemDebug(ctx, apiLogSubsystem, "Request to the Mailjet API failed", fields, map[string]interface{}{
+			"error": err.Error(),
+		})
+		return resp, err
+	}
+
+	fields["http_status_code"] = resp.StatusCode
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Received response from the Mailjet API", fields)
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+
+	tflog.SubsystemTrace(ctx, apiLogSubsystem, "Mailjet API response details", fields, map[string]interface{}{
+		"http_response_headers": redactHeaders(resp.Header),
+		"http_response_body":    string(body),
+	})
+
+	return resp, nil
+}
+
+func readRequestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	redactedHeaders := make(map[string]string, len(headers))
+	for name := range headers {
+		if name == "Authorization" {
+			redactedHeaders[name] = redactedValue
+			continue
+		}
+		redactedHeaders[name] = headers.Get(name)
+	}
+
+	return redactedHeaders
+}
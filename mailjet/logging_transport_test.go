@@ -0,0 +1,67 @@
+package mailjet
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"Count":1,"Data":[{"Name":"private-secret"}],"Total":1}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := newAPILogContext(tflogtest.RootLogger(context.Background(), &output), "private-secret")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v3/REST/sender", strings.NewReader(`{"Email":"*@example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.SetBasicAuth("public", "private-secret")
+
+	response, err := newLoggingTransport(http.DefaultTransport).RoundTrip(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(body), `{"Count":1,"Data":[{"Name":"private-secret"}],"Total":1}`); diff != "" {
+		t.Errorf("unexpected response body difference: %s", diff)
+	}
+
+	logs := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 log entries, got %d", len(entries))
+	}
+
+	if diff := cmp.Diff(entries[2]["http_status_code"], float64(http.StatusCreated)); diff != "" {
+		t.Errorf("unexpected logged status code difference: %s", diff)
+	}
+	if diff := cmp.Diff(entries[1]["http_request_body"], `{"Email":"*@example.com"}`); diff != "" {
+		t.Errorf("unexpected logged request body difference: %s", diff)
+	}
+	if strings.Contains(logs, "private-secret") || strings.Contains(logs, request.Header.Get("Authorization")) {
+		t.Errorf("secrets are present in the logs: %s", logs)
+	}
+}
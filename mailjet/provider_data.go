@@ -0,0 +1,75 @@
+package mailjet
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mailjet/mailjet-apiv3-go/v4"
+)
+
+// providerData holds the Mailjet client and the provider settings shared with every resource and data source
+type providerData struct {
+	client     *apiClient
+	httpClient *http.Client
+	// region is empty when a custom base URL is used
+	region         string
+	endpoints      mailjetEndpoints
+	maxRetries     int
+	retryMaxWait   time.Duration
+	requestTimeout time.Duration
+	readOnly       bool
+}
+
+// configureProviderData retrieves the provider data given to the Configure method of resources and data sources.
+// It returns nil when the provider has not been configured yet.
+func configureProviderData(data any, diags *diag.Diagnostics) *providerData {
+	if data == nil {
+		return nil
+	}
+
+	providerData, ok := data.(*providerData)
+	if !ok {
+		diags.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", data),
+		)
+
+		return nil
+	}
+
+	return providerData
+}
+
+// newClient builds an API client for the given API keys sharing the HTTP client and the settings of the provider
+func (d *providerData) newClient(apiKeyPublic string, apiKeyPrivate string) *apiClient {
+	mailjetClient := mailjet.NewMailjetClient(apiKeyPublic, apiKeyPrivate, d.endpoints.V3)
+	mailjetClient.SetClient(d.httpClient)
+
+	return newAPIClient(mailjetClient, d.endpoints.V3, d.requestTimeout, d.readOnly)
+}
+
+// clientFor returns the API client to use for an element, a dedicated one when its credentials block is set
+func (d *providerData) clientFor(credentials *credentialsModel) *apiClient {
+	if credentials == nil {
+		return d.client
+	}
+
+	return d.newClient(credentials.PublicKey.ValueString(), credentials.PrivateKey.ValueString())
+}
+
+// checkWritable reports an error when the provider is in read-only mode.
+// It must be called by every operation changing the Mailjet account before doing any call to the API.
+func (d *providerData) checkWritable(diags *diag.Diagnostics, operation string) bool {
+	if !d.readOnly {
+		return true
+	}
+
+	diags.AddError(
+		"Mailjet provider in read-only mode",
+		"Could not "+operation+": the provider is configured in read-only mode (read_only attribute or MJ_READ_ONLY environment variable), no changes can be made to the Mailjet account.",
+	)
+
+	return false
+}
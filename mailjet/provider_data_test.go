@@ -0,0 +1,99 @@
+package mailjet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderDataClientFor(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		publicKey  string
+		privateKey string
+		userAgent  string
+	}
+
+	type testCase struct {
+		credentials      *credentialsModel
+		expectedRequests []request
+	}
+
+	tests := map[string]testCase{
+		"provider_keys": {
+			expectedRequests: []request{
+				{publicKey: "provider-public", privateKey: "provider-private", userAgent: "terraform-provider-mailjet/test"},
+				{publicKey: "provider-public", privateKey: "provider-private", userAgent: "terraform-provider-mailjet/test"},
+			},
+		},
+		"credentials_block": {
+			credentials: &credentialsModel{
+				PublicKey:  types.StringValue("subaccount-public"),
+				PrivateKey: types.StringValue("subaccount-private"),
+			},
+			expectedRequests: []request{
+				{publicKey: "subaccount-public", privateKey: "subaccount-private", userAgent: "terraform-provider-mailjet/test"},
+				{publicKey: "subaccount-public", privateKey: "subaccount-private", userAgent: "terraform-provider-mailjet/test"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			requests := make(chan request, 10)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				publicKey, privateKey, _ := r.BasicAuth()
+				requests <- request{publicKey: publicKey, privateKey: privateKey, userAgent: r.Header.Get("User-Agent")}
+
+				// The first attempt is rejected to check the retry transport of the provider is used
+				if attempts.Add(1) == 1 {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"Count": 1, "Data": [{"ID": 1}], "Total": 1}`))
+			}))
+			t.Cleanup(server.Close)
+
+			httpClient, err := newHTTPClient(httpClientConfig{userAgent: "terraform-provider-mailjet/test", maxRetries: 1, retryMaxWait: time.Second})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data := &providerData{httpClient: httpClient, endpoints: endpointsFromBaseURL(server.URL + "/v3"), requestTimeout: time.Minute}
+			data.client = data.newClient("provider-public", "provider-private")
+
+			client := data.clientFor(test.credentials)
+			if test.credentials == nil && client != data.client {
+				t.Errorf("expected the provider client to be used without credentials block")
+			}
+			// Sharing the HTTP client shares the rate limiter of the provider between all the API keys
+			if client.client.Client() != httpClient {
+				t.Errorf("expected the shared HTTP client of the provider to be used")
+			}
+			if _, err := client.GetMyProfile(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			close(requests)
+			var actualRequests []request
+			for r := range requests {
+				actualRequests = append(actualRequests, r)
+			}
+			if diff := cmp.Diff(actualRequests, test.expectedRequests, cmp.AllowUnexported(request{})); diff != "" {
+				t.Errorf("unexpected requests difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,553 @@
+package mailjet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// setTestProviderEnv isolates Configure from the environment of the machine running the tests
+func setTestProviderEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+	for _, name := range []string{"MJ_APIKEY_PUBLIC", "MJ_APIKEY_PRIVATE", "MJ_BASE_URL", "MJ_PROFILE", "MJ_READ_ONLY"} {
+		t.Setenv(name, "")
+	}
+}
+
+func configureTestProvider(t *testing.T, config mailjetProviderModel) provider.ConfigureResponse {
+	t.Helper()
+
+	p := &mailjetProvider{version: "test"}
+
+	var schemaResponse provider.SchemaResponse
+	p.Schema(context.Background(), provider.SchemaRequest{}, &schemaResponse)
+
+	state := tfsdk.State{Schema: schemaResponse.Schema}
+	if diags := state.Set(context.Background(), config); diags.HasError() {
+		t.Fatalf("unable to build the provider configuration: %v", diags)
+	}
+
+	req := provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResponse.Schema, Raw: state.Raw}}
+	var resp provider.ConfigureResponse
+	p.Configure(context.Background(), req, &resp)
+
+	return resp
+}
+
+// diagnosticSummaries lists the errors as "attribute: summary" to check they are reported on the right attributes
+func diagnosticSummaries(diags diag.Diagnostics) []string {
+	var summaries []string
+	for _, d := range diags.Errors() {
+		summary := d.Summary()
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			summary = withPath.Path().String() + ": " + summary
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+func TestProviderConfigureAPIKeys(t *testing.T) {
+	type testCase struct {
+		config            mailjetProviderModel
+		env               map[string]string
+		expectedSummaries []string
+	}
+
+	tests := map[string]testCase{
+		"attributes": {
+			config: mailjetProviderModel{
+				PublicAPIKey:  types.StringValue("public"),
+				PrivateAPIKey: types.StringValue("private"),
+			},
+		},
+		"environment": {
+			env: map[string]string{"MJ_APIKEY_PUBLIC": "public", "MJ_APIKEY_PRIVATE": "private"},
+		},
+		"missing": {
+			expectedSummaries: []string{
+				"api_key_public: Missing Mailjet public API key",
+				"api_key_private: Missing Mailjet private API key",
+			},
+		},
+		"missing_private": {
+			config: mailjetProviderModel{PublicAPIKey: types.StringValue("public")},
+			expectedSummaries: []string{
+				"api_key_private: Missing Mailjet private API key",
+			},
+		},
+		"unknown": {
+			config: mailjetProviderModel{
+				PublicAPIKey:  types.StringUnknown(),
+				PrivateAPIKey: types.StringValue("private"),
+			},
+			expectedSummaries: []string{
+				"api_key_public: Unknown provider configuration value",
+			},
+		},
+		"unknown_private": {
+			config: mailjetProviderModel{
+				PublicAPIKey:  types.StringValue("public"),
+				PrivateAPIKey: types.StringUnknown(),
+			},
+			expectedSummaries: []string{
+				"api_key_private: Unknown provider configuration value",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			setTestProviderEnv(t)
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+
+			test.config.SkipCredentialsValidation = types.BoolValue(true)
+			resp := configureTestProvider(t, test.config)
+
+			if diff := cmp.Diff(diagnosticSummaries(resp.Diagnostics), test.expectedSummaries); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		statusCode        int
+		expectedSummaries []string
+	}
+
+	tests := map[string]testCase{
+		"valid": {
+			statusCode: http.StatusOK,
+		},
+		"unauthorized": {
+			statusCode:        http.StatusUnauthorized,
+			expectedSummaries: []string{"Invalid Mailjet API credentials"},
+		},
+		"forbidden": {
+			statusCode:        http.StatusForbidden,
+			expectedSummaries: []string{"Invalid Mailjet API credentials"},
+		},
+		"server_error": {
+			statusCode:        http.StatusInternalServerError,
+			expectedSummaries: []string{"Unable to validate Mailjet API credentials"},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/v3/REST/myprofile" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.statusCode)
+				_, _ = w.Write([]byte(`{"Count": 1, "Data": [{"ID": 1}], "Total": 1}`))
+			}, time.Minute)
+
+			var diags diag.Diagnostics
+			validateCredentials(context.Background(), client, &diags)
+
+			if diff := cmp.Diff(diagnosticSummaries(diags), test.expectedSummaries); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureReadOnly(t *testing.T) {
+	type testCase struct {
+		readOnly          types.Bool
+		env               string
+		expectedReadOnly  bool
+		expectedSummaries []string
+	}
+
+	tests := map[string]testCase{
+		"default": {
+			readOnly: types.BoolNull(),
+		},
+		"attribute": {
+			readOnly:         types.BoolValue(true),
+			expectedReadOnly: true,
+		},
+		"environment": {
+			readOnly:         types.BoolNull(),
+			env:              "true",
+			expectedReadOnly: true,
+		},
+		"environment_disabled": {
+			readOnly: types.BoolNull(),
+			env:      "false",
+		},
+		"environment_disabled_attribute_enabled": {
+			readOnly:         types.BoolValue(true),
+			env:              "false",
+			expectedReadOnly: true,
+		},
+		"environment_not_overridden_by_attribute": {
+			readOnly:         types.BoolValue(false),
+			env:              "true",
+			expectedReadOnly: true,
+		},
+		"invalid_environment": {
+			readOnly:          types.BoolValue(false),
+			env:               "maybe",
+			expectedSummaries: []string{"read_only: Invalid MJ_READ_ONLY environment variable"},
+		},
+		"unknown": {
+			readOnly:          types.BoolUnknown(),
+			expectedSummaries: []string{"read_only: Unknown provider configuration value"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			setTestProviderEnv(t)
+			t.Setenv("MJ_READ_ONLY", test.env)
+
+			resp := configureTestProvider(t, mailjetProviderModel{
+				PublicAPIKey:              types.StringValue("public"),
+				PrivateAPIKey:             types.StringValue("private"),
+				SkipCredentialsValidation: types.BoolValue(true),
+				ReadOnly:                  test.readOnly,
+			})
+
+			if diff := cmp.Diff(diagnosticSummaries(resp.Diagnostics), test.expectedSummaries); diff != "" {
+				t.Fatalf("unexpected diagnostics difference: %s", diff)
+			}
+			if test.expectedSummaries != nil {
+				return
+			}
+
+			data, ok := resp.ResourceData.(*providerData)
+			if !ok {
+				t.Fatalf("expected the provider data to be set, got: %T", resp.ResourceData)
+			}
+			if data.readOnly != test.expectedReadOnly || data.client.readOnly != test.expectedReadOnly {
+				t.Errorf("expected the read-only mode to be %t, got %t (API client: %t)", test.expectedReadOnly, data.readOnly, data.client.readOnly)
+			}
+		})
+	}
+}
+
+func TestLoadCredentialsProfiles(t *testing.T) {
+	const validContent = `
+[default]
+api_key_public = default-public
+api_key_private = default-private
+
+[staging]
+api_key_public = staging-public
+api_key_private = staging-private
+region = us
+`
+	const invalidContent = "[default]\napi_key_secret = secret\n"
+
+	type testCase struct {
+		defaultFileContent      string
+		explicitFileContent     string
+		explicitFile            string
+		profile                 types.String
+		profileEnv              string
+		keysProvided            bool
+		expectedSelectedProfile credentialsProfile
+		expectedDefaultProfile  credentialsProfile
+		expectedSummaries       []string
+	}
+
+	tests := map[string]testCase{
+		"no_file": {},
+		"default_profile": {
+			defaultFileContent:     validContent,
+			expectedDefaultProfile: credentialsProfile{APIKeyPublic: "default-public", APIKeyPrivate: "default-private"},
+		},
+		"profile_attribute": {
+			defaultFileContent:      validContent,
+			profile:                 types.StringValue("staging"),
+			expectedSelectedProfile: credentialsProfile{APIKeyPublic: "staging-public", APIKeyPrivate: "staging-private", Region: regionUS},
+		},
+		"profile_environment": {
+			defaultFileContent:      validContent,
+			profileEnv:              "staging",
+			expectedSelectedProfile: credentialsProfile{APIKeyPublic: "staging-public", APIKeyPrivate: "staging-private", Region: regionUS},
+		},
+		"profile_attribute_over_environment": {
+			defaultFileContent:      validContent,
+			profile:                 types.StringValue("default"),
+			profileEnv:              "staging",
+			expectedSelectedProfile: credentialsProfile{APIKeyPublic: "default-public", APIKeyPrivate: "default-private"},
+		},
+		"unknown_profile": {
+			defaultFileContent: validContent,
+			profile:            types.StringValue("production"),
+			expectedSummaries:  []string{"profile: Unknown credentials profile"},
+		},
+		"profile_without_file": {
+			profile:           types.StringValue("staging"),
+			expectedSummaries: []string{"shared_credentials_file: Unable to read the shared credentials file"},
+		},
+		"explicit_file": {
+			defaultFileContent:     invalidContent,
+			explicitFileContent:    validContent,
+			explicitFile:           "~/mailjet.ini",
+			expectedDefaultProfile: credentialsProfile{APIKeyPublic: "default-public", APIKeyPrivate: "default-private"},
+		},
+		"missing_explicit_file": {
+			explicitFile:      "~/mailjet.ini",
+			keysProvided:      true,
+			expectedSummaries: []string{"shared_credentials_file: Unable to read the shared credentials file"},
+		},
+		"invalid_explicit_file": {
+			explicitFileContent: invalidContent,
+			explicitFile:        "~/mailjet.ini",
+			keysProvided:        true,
+			expectedSummaries:   []string{"shared_credentials_file: Unable to read the shared credentials file"},
+		},
+		"invalid_default_file_not_needed": {
+			defaultFileContent: invalidContent,
+			keysProvided:       true,
+		},
+		"invalid_default_file_without_keys": {
+			defaultFileContent: invalidContent,
+			expectedSummaries:  []string{"shared_credentials_file: Unable to read the shared credentials file"},
+		},
+		"invalid_default_file_with_profile": {
+			defaultFileContent: invalidContent,
+			profileEnv:         "staging",
+			keysProvided:       true,
+			expectedSummaries:  []string{"shared_credentials_file: Unable to read the shared credentials file"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			setTestProviderEnv(t)
+			t.Setenv("MJ_PROFILE", test.profileEnv)
+			home, _ := os.UserHomeDir()
+
+			if test.defaultFileContent != "" {
+				writeTestFile(t, filepath.Join(home, ".mailjet", "credentials"), test.defaultFileContent)
+			}
+			if test.explicitFileContent != "" {
+				writeTestFile(t, filepath.Join(home, strings.TrimPrefix(test.explicitFile, "~/")), test.explicitFileContent)
+			}
+
+			config := mailjetProviderModel{Profile: test.profile}
+			if test.explicitFile != "" {
+				config.SharedCredentialsFile = types.StringValue(test.explicitFile)
+			}
+
+			var diags diag.Diagnostics
+			selectedProfile, defaultProfile := loadCredentialsProfiles(config, test.keysProvided, &diags)
+
+			if diff := cmp.Diff(diagnosticSummaries(diags), test.expectedSummaries); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+			if diff := cmp.Diff(selectedProfile, test.expectedSelectedProfile); diff != "" {
+				t.Errorf("unexpected selected profile difference: %s", diff)
+			}
+			if diff := cmp.Diff(defaultProfile, test.expectedDefaultProfile); diff != "" {
+				t.Errorf("unexpected default profile difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureAPIKeysPrecedence(t *testing.T) {
+	const credentialsContent = `
+[default]
+api_key_public = default-public
+api_key_private = default-private
+
+[staging]
+api_key_public = staging-public
+api_key_private = staging-private
+`
+
+	type testCase struct {
+		config             mailjetProviderModel
+		env                map[string]string
+		expectedPublicKey  string
+		expectedPrivateKey string
+	}
+
+	tests := map[string]testCase{
+		"default_profile": {
+			expectedPublicKey:  "default-public",
+			expectedPrivateKey: "default-private",
+		},
+		"environment_over_default_profile": {
+			env:                map[string]string{"MJ_APIKEY_PUBLIC": "env-public", "MJ_APIKEY_PRIVATE": "env-private"},
+			expectedPublicKey:  "env-public",
+			expectedPrivateKey: "env-private",
+		},
+		"selected_profile_over_environment": {
+			config:             mailjetProviderModel{Profile: types.StringValue("staging")},
+			env:                map[string]string{"MJ_APIKEY_PUBLIC": "env-public", "MJ_APIKEY_PRIVATE": "env-private"},
+			expectedPublicKey:  "staging-public",
+			expectedPrivateKey: "staging-private",
+		},
+		"attributes_over_selected_profile": {
+			config: mailjetProviderModel{
+				PublicAPIKey:  types.StringValue("attribute-public"),
+				PrivateAPIKey: types.StringValue("attribute-private"),
+			},
+			env:                map[string]string{"MJ_PROFILE": "staging"},
+			expectedPublicKey:  "attribute-public",
+			expectedPrivateKey: "attribute-private",
+		},
+		"mixed_sources": {
+			config:             mailjetProviderModel{PublicAPIKey: types.StringValue("attribute-public")},
+			env:                map[string]string{"MJ_APIKEY_PRIVATE": "env-private"},
+			expectedPublicKey:  "attribute-public",
+			expectedPrivateKey: "env-private",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			setTestProviderEnv(t)
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			home, _ := os.UserHomeDir()
+			writeTestFile(t, filepath.Join(home, ".mailjet", "credentials"), credentialsContent)
+
+			var publicKey, privateKey string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				publicKey, privateKey, _ = r.BasicAuth()
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"Count": 1, "Data": [{"ID": 1}], "Total": 1}`))
+			}))
+			t.Cleanup(server.Close)
+
+			test.config.BaseURL = types.StringValue(server.URL + "/v3")
+			resp := configureTestProvider(t, test.config)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if publicKey != test.expectedPublicKey || privateKey != test.expectedPrivateKey {
+				t.Errorf("expected the API keys %s:%s, got %s:%s", test.expectedPublicKey, test.expectedPrivateKey, publicKey, privateKey)
+			}
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, name string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProviderConfigureUnknownValues(t *testing.T) {
+	tests := map[string]func(config *mailjetProviderModel){
+		"base_url":                    func(config *mailjetProviderModel) { config.BaseURL = types.StringUnknown() },
+		"region":                      func(config *mailjetProviderModel) { config.Region = types.StringUnknown() },
+		"profile":                     func(config *mailjetProviderModel) { config.Profile = types.StringUnknown() },
+		"shared_credentials_file":     func(config *mailjetProviderModel) { config.SharedCredentialsFile = types.StringUnknown() },
+		"skip_credentials_validation": func(config *mailjetProviderModel) { config.SkipCredentialsValidation = types.BoolUnknown() },
+		"max_retries":                 func(config *mailjetProviderModel) { config.MaxRetries = types.Int64Unknown() },
+		"retry_max_wait":              func(config *mailjetProviderModel) { config.RetryMaxWait = types.StringUnknown() },
+		"request_timeout":             func(config *mailjetProviderModel) { config.RequestTimeout = types.StringUnknown() },
+		"max_requests_per_second":     func(config *mailjetProviderModel) { config.MaxRequestsPerSecond = types.Float64Unknown() },
+		"max_concurrent_requests":     func(config *mailjetProviderModel) { config.MaxConcurrentRequests = types.Int64Unknown() },
+		"user_agent_suffix":           func(config *mailjetProviderModel) { config.UserAgentSuffix = types.StringUnknown() },
+		"http_proxy":                  func(config *mailjetProviderModel) { config.HTTPProxy = types.StringUnknown() },
+		"ca_cert_file":                func(config *mailjetProviderModel) { config.CACertFile = types.StringUnknown() },
+		"ca_cert_pem":                 func(config *mailjetProviderModel) { config.CACertPEM = types.StringUnknown() },
+		"insecure_skip_verify":        func(config *mailjetProviderModel) { config.InsecureSkipVerify = types.BoolUnknown() },
+	}
+
+	for name, setUnknown := range tests {
+		t.Run(name, func(t *testing.T) {
+			setTestProviderEnv(t)
+
+			config := mailjetProviderModel{
+				PublicAPIKey:              types.StringValue("public"),
+				PrivateAPIKey:             types.StringValue("private"),
+				SkipCredentialsValidation: types.BoolValue(true),
+			}
+			setUnknown(&config)
+			resp := configureTestProvider(t, config)
+
+			if diff := cmp.Diff(diagnosticSummaries(resp.Diagnostics), []string{name + ": Unknown provider configuration value"}); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+			if resp.ResourceData != nil {
+				t.Errorf("expected the provider not to be configured")
+			}
+		})
+	}
+}
+
+func TestProviderConfigureProviderData(t *testing.T) {
+	setTestProviderEnv(t)
+
+	resp := configureTestProvider(t, mailjetProviderModel{
+		PublicAPIKey:              types.StringValue("public"),
+		PrivateAPIKey:             types.StringValue("private"),
+		SkipCredentialsValidation: types.BoolValue(true),
+		Region:                    types.StringValue(regionUS),
+		MaxRetries:                types.Int64Value(5),
+		RetryMaxWait:              types.StringValue("10s"),
+		RequestTimeout:            types.StringValue("2m"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	data, ok := resp.ResourceData.(*providerData)
+	if !ok {
+		t.Fatalf("expected the provider data to be set, got: %T", resp.ResourceData)
+	}
+	if resp.DataSourceData != resp.ResourceData {
+		t.Errorf("expected the data sources and the resources to share the provider data")
+	}
+
+	expected := providerData{
+		region:         regionUS,
+		endpoints:      regionEndpoints[regionUS],
+		maxRetries:     5,
+		retryMaxWait:   10 * time.Second,
+		requestTimeout: 2 * time.Minute,
+	}
+	actual := providerData{
+		region:         data.region,
+		endpoints:      data.endpoints,
+		maxRetries:     data.maxRetries,
+		retryMaxWait:   data.retryMaxWait,
+		requestTimeout: data.requestTimeout,
+	}
+	if diff := cmp.Diff(actual, expected, cmp.AllowUnexported(providerData{})); diff != "" {
+		t.Errorf("unexpected provider data difference: %s", diff)
+	}
+}
@@ -0,0 +1,108 @@
+package mailjet
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRequestsPerSecond  = 10
+	defaultMaxConcurrentRequests = 4
+)
+
+var _ http.RoundTripper = &rateLimitTransport{}
+
+// rateLimitTransport smooths the requests sent to the Mailjet API by the concurrent operations of Terraform.
+// A zero limit disables the corresponding restriction.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *tokenBucket
+	slots   chan struct{}
+}
+
+func newRateLimitTransport(next http.RoundTripper, maxRequestsPerSecond float64, maxConcurrentRequests int) *rateLimitTransport {
+	transport := &rateLimitTransport{
+		next: next,
+	}
+	if maxRequestsPerSecond > 0 {
+		transport.limiter = newTokenBucket(maxRequestsPerSecond)
+	}
+	if maxConcurrentRequests > 0 {
+		transport.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return transport
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+			defer func() { <-t.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// tokenBucket allows one request at a time to go through at the configured rate
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		tokens: 1,
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := b.reserve()
+		if wait == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token when one is available, otherwise it returns how long to wait for the next one
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(1, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return max(time.Duration((1-b.tokens)/b.rate*float64(time.Second)), time.Millisecond)
+}
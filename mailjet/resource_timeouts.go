@@ -0,0 +1,19 @@
+package mailjet
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultOperationTimeout bounds the operations of the resources when no timeouts block is configured
+const defaultOperationTimeout = 5 * time.Minute
+
+// nullTimeouts is the value of an unset timeouts block, it is needed when building a state from scratch
+func nullTimeouts(ctx context.Context) timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(timeouts.BlockAll(ctx).Type().(timeouts.Type).AttrTypes),
+	}
+}
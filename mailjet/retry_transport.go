@@ -0,0 +1,140 @@
+package mailjet
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryMaxWait = 30 * time.Second
+	retryBaseWait       = time.Second
+)
+
+var _ http.RoundTripper = &retryTransport{}
+
+// retryTransport retries requests rejected by the Mailjet API because of rate limiting (HTTP 429)
+// or transient server failures (HTTP 5xx) using an exponential backoff with jitter.
+// Non idempotent requests are only retried when the response guarantees they have not been processed (HTTP 429 and 503).
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	baseWait   time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		baseWait:   retryBaseWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.waitDuration(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	attemptReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attemptReq.Body = body
+	}
+
+	return attemptReq, nil
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// The request might have been processed, only retry when doing it again is harmless
+		return isIdempotent(req)
+	}
+
+	// Those responses guarantee the request has not been processed
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		return true
+	}
+
+	// Other server errors, e.g. a gateway timeout, can happen after the request has been processed
+	return isIdempotent(req) && resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+func isIdempotent(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodPut || req.Method == http.MethodDelete
+}
+
+func (t *retryTransport) waitDuration(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(retryAfter, t.maxWait)
+		}
+	}
+
+	wait := t.maxWait
+	if attempt < 32 && t.baseWait<<attempt > 0 {
+		wait = min(t.baseWait<<attempt, t.maxWait)
+	}
+
+	halfWait := wait / 2
+	return halfWait + rand.N(halfWait+1) //nolint:gosec // Jitter does not need a cryptographically secure random source
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(time.Until(date), 0), true
+}
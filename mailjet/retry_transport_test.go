@@ -0,0 +1,177 @@
+package mailjet
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		method             string
+		statusCodes        []int
+		maxRetries         int
+		expectedStatusCode int
+		expectedAttempts   int32
+	}
+
+	tests := map[string]testCase{
+		"success_first_attempt": {
+			method:             http.MethodGet,
+			statusCodes:        []int{http.StatusOK},
+			maxRetries:         3,
+			expectedStatusCode: http.StatusOK,
+			expectedAttempts:   1,
+		},
+		"rate_limited_then_success": {
+			method:             http.MethodPost,
+			statusCodes:        []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusCreated},
+			maxRetries:         3,
+			expectedStatusCode: http.StatusCreated,
+			expectedAttempts:   3,
+		},
+		"server_error_then_success": {
+			method:             http.MethodPut,
+			statusCodes:        []int{http.StatusBadGateway, http.StatusOK},
+			maxRetries:         3,
+			expectedStatusCode: http.StatusOK,
+			expectedAttempts:   2,
+		},
+		"unavailable_then_success_post": {
+			method:             http.MethodPost,
+			statusCodes:        []int{http.StatusServiceUnavailable, http.StatusCreated},
+			maxRetries:         3,
+			expectedStatusCode: http.StatusCreated,
+			expectedAttempts:   2,
+		},
+		"no_retry_on_server_error_post": {
+			method:             http.MethodPost,
+			statusCodes:        []int{http.StatusInternalServerError, http.StatusCreated},
+			maxRetries:         3,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedAttempts:   1,
+		},
+		"no_retry_on_gateway_timeout_post": {
+			method:             http.MethodPost,
+			statusCodes:        []int{http.StatusGatewayTimeout, http.StatusCreated},
+			maxRetries:         3,
+			expectedStatusCode: http.StatusGatewayTimeout,
+			expectedAttempts:   1,
+		},
+		"give_up_after_max_retries": {
+			method:             http.MethodGet,
+			statusCodes:        []int{http.StatusServiceUnavailable},
+			maxRetries:         2,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedAttempts:   3,
+		},
+		"no_retry_on_client_error": {
+			method:             http.MethodGet,
+			statusCodes:        []int{http.StatusNotFound},
+			maxRetries:         3,
+			expectedStatusCode: http.StatusNotFound,
+			expectedAttempts:   1,
+		},
+		"retries_disabled": {
+			method:             http.MethodGet,
+			statusCodes:        []int{http.StatusTooManyRequests},
+			maxRetries:         0,
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedAttempts:   1,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != "payload" {
+					t.Errorf("unexpected request body on attempt %d: %q", attempts.Load(), body)
+				}
+				attempt := int(attempts.Add(1)) - 1
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(test.statusCodes[min(attempt, len(test.statusCodes)-1)])
+			}))
+			defer server.Close()
+
+			transport := newRetryTransport(http.DefaultTransport, test.maxRetries, time.Second)
+			transport.baseWait = time.Millisecond
+
+			request, err := http.NewRequest(test.method, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := transport.RoundTrip(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+
+			if diff := cmp.Diff(response.StatusCode, test.expectedStatusCode); diff != "" {
+				t.Errorf("unexpected status code difference: %s", diff)
+			}
+			if diff := cmp.Diff(attempts.Load(), test.expectedAttempts); diff != "" {
+				t.Errorf("unexpected number of attempts difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		val              string
+		expectedDuration time.Duration
+		expectedOk       bool
+	}
+
+	tests := map[string]testCase{
+		"empty": {
+			val: "",
+		},
+		"seconds": {
+			val:              "12",
+			expectedDuration: 12 * time.Second,
+			expectedOk:       true,
+		},
+		"negative_seconds": {
+			val: "-1",
+		},
+		"date_in_the_past": {
+			val:        "Wed, 21 Oct 2015 07:28:00 GMT",
+			expectedOk: true,
+		},
+		"invalid": {
+			val: "soon",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			duration, ok := parseRetryAfter(test.val)
+
+			if diff := cmp.Diff(duration, test.expectedDuration); diff != "" {
+				t.Errorf("unexpected duration difference: %s", diff)
+			}
+			if diff := cmp.Diff(ok, test.expectedOk); diff != "" {
+				t.Errorf("unexpected ok difference: %s", diff)
+			}
+		})
+	}
+}
@@ -4,25 +4,36 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mailjet/mailjet-apiv3-go/v3/resources"
-	"github.com/mailjet/mailjet-apiv3-go/v4"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 var (
-	_ resource.Resource                = &senderResource{}
-	_ resource.ResourceWithConfigure   = &senderResource{}
-	_ resource.ResourceWithImportState = &senderResource{}
+	_ resource.Resource                 = &senderResource{}
+	_ resource.ResourceWithConfigure    = &senderResource{}
+	_ resource.ResourceWithImportState  = &senderResource{}
+	_ resource.ResourceWithUpgradeState = &senderResource{}
+)
+
+const (
+	senderStatusActive  = "Active"
+	senderStatusDeleted = "Deleted"
 )
 
 func NewSenderResource() resource.Resource {
@@ -30,25 +41,11 @@ func NewSenderResource() resource.Resource {
 }
 
 type senderResource struct {
-	client *mailjet.Client
+	providerData *providerData
 }
 
 func (r *senderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
-	}
-
-	client, ok := req.ProviderData.(*mailjet.Client)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *mailjet.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-
-		return
-	}
-
-	r.client = client
+	r.providerData = configureProviderData(req.ProviderData, &resp.Diagnostics)
 }
 
 func (r *senderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,25 +54,38 @@ func (r *senderResource) Metadata(_ context.Context, req resource.MetadataReques
 
 func (r *senderResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 2,
 		Attributes: map[string]schema.Attribute{
 			"email": schema.StringAttribute{
 				Required:    true,
 				Description: "The email address for this sender. To register a domain use *@example.com.",
+				Validators: []validator.String{
+					EmailAddressOrDomainWildcard(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"name": schema.StringAttribute{
-				Required:    true,
-				Description: "User-provided name for this sender.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "User-provided name for this sender. Defaults to an empty name.",
 			},
 			"is_default_sender": schema.BoolAttribute{
-				Required:    true,
-				Description: "Indicates whether this is the default sender or not.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Indicates whether this is the default sender or not. Defaults to false.",
 			},
 			"email_type": schema.StringAttribute{
-				Required:    true,
-				Description: "Type of emails this sender will send. This is for purely informative purposes - the values do not place any sending restrictions on the sender email or domain. Can be transactional, bulk or unknown",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("unknown"),
+				Description: "Type of emails this sender will send. This is for purely informative purposes - the values do not place any sending restrictions on the sender email or domain. Can be transactional, bulk or unknown, defaults to unknown.",
+				Validators: []validator.String{
+					StringOneOf("transactional", "bulk", "unknown"),
+				},
 			},
 			"dns_id": schema.Int64Attribute{
 				Computed:    true,
@@ -93,26 +103,69 @@ func (r *senderResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 			},
 			"created_at": schema.StringAttribute{
 				Computed:    true,
-				Description: "Timestamp indicating when this sender object was created.",
+				Description: "Timestamp indicating when this sender object was created, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Status of this sender: Inactive, Active or Deleted. A sender can only be used once Active, see the mailjet_sender_validate resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_domain_wildcard": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Indicates whether this sender covers a whole domain (*@example.com) or a single email address.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Computed:    true,
+				Description: "Domain of the email address of this sender.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"validation_filename": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the file to put at the root of the website of the domain to validate this sender without a DNS record.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"credentials": credentialsResourceBlock(),
+			"timeouts":    timeouts.BlockAll(ctx),
 		},
 	}
 }
 
 type senderResourceModel struct {
-	Email           types.String `tfsdk:"email"`
-	Name            types.String `tfsdk:"name"`
-	IsDefaultSender types.Bool   `tfsdk:"is_default_sender"`
-	EmailType       types.String `tfsdk:"email_type"`
-	ID              types.Int64  `tfsdk:"id"`
-	DNSID           types.Int64  `tfsdk:"dns_id"`
-	CreatedAt       types.String `tfsdk:"created_at"`
+	Email              types.String `tfsdk:"email"`
+	Name               types.String `tfsdk:"name"`
+	IsDefaultSender    types.Bool   `tfsdk:"is_default_sender"`
+	EmailType          types.String `tfsdk:"email_type"`
+	ID                 types.Int64  `tfsdk:"id"`
+	DNSID              types.Int64  `tfsdk:"dns_id"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	Status             types.String `tfsdk:"status"`
+	IsDomainWildcard   types.Bool   `tfsdk:"is_domain_wildcard"`
+	Domain             types.String `tfsdk:"domain"`
+	ValidationFilename types.String `tfsdk:"validation_filename"`
+
+	Credentials *credentialsModel `tfsdk:"credentials"`
+	Timeouts    timeouts.Value    `tfsdk:"timeouts"`
 }
 
 func (r *senderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.providerData.checkWritable(&resp.Diagnostics, "create the Mailjet sender") {
+		return
+	}
+
 	var plan senderResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 
@@ -121,15 +174,13 @@ func (r *senderResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	if plan.Name.IsNull() {
-		plan.Name = types.StringValue("")
-	}
-	if plan.EmailType.IsNull() {
-		plan.EmailType = types.StringValue("unknown")
-	}
-	if plan.IsDefaultSender.IsNull() {
-		plan.IsDefaultSender = types.BoolValue(false)
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	senderToCreate := resources.Sender{
 		Email:           plan.Email.ValueString(),
@@ -138,16 +189,13 @@ func (r *senderResource) Create(ctx context.Context, req resource.CreateRequest,
 		IsDefaultSender: plan.IsDefaultSender.ValueBool(),
 	}
 
-	senderSearchRequest := &mailjet.Request{
-		Resource: "sender",
-		AltID:    senderToCreate.Email,
-	}
-	var responseDataSearch []resources.Sender
-	err := r.client.Get(senderSearchRequest, &responseDataSearch)
+	client := r.providerData.clientFor(plan.Credentials)
+
+	responseDataSearch, err := client.FindSendersByEmail(ctx, senderToCreate.Email)
 
-	if err == nil && len(responseDataSearch) == 1 && responseDataSearch[0].Status == "Deleted" {
+	if err == nil && len(responseDataSearch) == 1 && responseDataSearch[0].Status == senderStatusDeleted {
 		plan.ID = types.Int64Value(responseDataSearch[0].ID)
-		err := r.updateSender(&senderToCreate)
+		err := client.UpdateSender(ctx, plan.ID.ValueInt64(), &senderToCreate)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to update existing Mailjet sender information",
@@ -156,17 +204,9 @@ func (r *senderResource) Create(ctx context.Context, req resource.CreateRequest,
 			return
 		}
 
-		r.updateStateWithFetchedSenderInformation(&plan, &resp.Diagnostics)
+		r.updateStateWithFetchedSenderInformation(ctx, client, &plan, &resp.Diagnostics)
 	} else {
-		mailjetFullUpdateRequest := &mailjet.FullRequest{
-			Info: &mailjet.Request{
-				Resource: "sender",
-			},
-			Payload: senderToCreate,
-		}
-		var responseData []resources.Sender
-
-		err = r.client.Post(mailjetFullUpdateRequest, &responseData)
+		responseData, err := client.CreateSender(ctx, &senderToCreate)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to to create a Mailjet sender",
@@ -198,7 +238,32 @@ func (r *senderResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	r.updateStateWithFetchedSenderInformation(&state, &resp.Diagnostics)
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	sender, err := r.providerData.clientFor(state.Credentials).FindSender(ctx, state.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mailjet sender information",
+			"Could not read sender #"+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	if sender == nil || sender.Status == senderStatusDeleted {
+		tflog.Warn(ctx, "Mailjet sender deleted outside of Terraform, removing it from the state", map[string]interface{}{
+			"id": state.ID.ValueInt64(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.refreshState(sender, &state)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -207,13 +272,8 @@ func (r *senderResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 }
 
-func (r *senderResource) updateStateWithFetchedSenderInformation(state *senderResourceModel, diags *diag.Diagnostics) {
-	var responseData []resources.Sender
-	mailjetRequest := &mailjet.Request{
-		Resource: "sender",
-		ID:       state.ID.ValueInt64(),
-	}
-	err := r.client.Get(mailjetRequest, &responseData)
+func (r *senderResource) updateStateWithFetchedSenderInformation(ctx context.Context, client *apiClient, state *senderResourceModel, diags *diag.Diagnostics) {
+	sender, err := client.FindSender(ctx, state.ID.ValueInt64())
 	if err != nil {
 		diags.AddError(
 			"Unable to read Mailjet sender information",
@@ -222,41 +282,67 @@ func (r *senderResource) updateStateWithFetchedSenderInformation(state *senderRe
 		return
 	}
 
-	if len(responseData) != 1 {
+	if sender == nil {
 		diags.AddError(
-			"Retrieved Mailjet sender information are not coherent",
-			"Could not read sender #"+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+fmt.Sprintf("Could not read sender #Expected 1 response entry, got %d", len(responseData)),
+			"Unable to read Mailjet sender information",
+			"Could not read sender #"+strconv.FormatInt(state.ID.ValueInt64(), 10)+": the sender does not exist",
 		)
 		return
 	}
 
-	r.refreshState(&responseData[0], state)
+	r.refreshState(sender, state)
 }
 
 func (r *senderResource) refreshState(responseData *resources.Sender, state *senderResourceModel) {
 	state.Email = types.StringValue(responseData.Email)
 	state.EmailType = types.StringValue(responseData.EmailType)
 	state.Name = types.StringValue(responseData.Name)
+	state.IsDefaultSender = types.BoolValue(responseData.IsDefaultSender)
 	state.ID = types.Int64Value(responseData.ID)
 	state.DNSID = types.Int64Value(responseData.DNSID)
-	state.CreatedAt = types.StringValue(responseData.CreatedAt.Format(time.RFC850))
+	state.CreatedAt = timestampValue(responseData.CreatedAt)
+	state.Status = types.StringValue(responseData.Status)
+	state.IsDomainWildcard = types.BoolValue(strings.HasPrefix(responseData.Email, "*@"))
+	state.Domain = types.StringValue(responseData.Email[strings.LastIndex(responseData.Email, "@")+1:])
+	state.ValidationFilename = types.StringValue(responseData.Filename)
 }
 
 func (r *senderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.providerData.checkWritable(&resp.Diagnostics, "update the Mailjet sender") {
+		return
+	}
+
 	var plan senderResourceModel
-	diags := req.State.Get(ctx, &plan)
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var id types.Int64
+	diags = req.State.GetAttribute(ctx, path.Root("id"), &id)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.ID = id
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	senderUpdate := resources.Sender{
-		Email:           plan.Email.ValueString(),
 		Name:            plan.Name.ValueString(),
 		EmailType:       plan.EmailType.ValueString(),
 		IsDefaultSender: plan.IsDefaultSender.ValueBool(),
 	}
-	err := r.updateSender(&senderUpdate)
+	client := r.providerData.clientFor(plan.Credentials)
+
+	err := client.UpdateSender(ctx, plan.ID.ValueInt64(), &senderUpdate)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update Mailjet sender information",
@@ -265,27 +351,20 @@ func (r *senderResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	r.updateStateWithFetchedSenderInformation(&plan, &resp.Diagnostics)
-}
-
-func (r *senderResource) updateSender(sender *resources.Sender) error {
-	mailjetRequest := &mailjet.Request{
-		Resource: "sender",
-		AltID:    sender.Email,
-	}
-	mailjetFullRequest := &mailjet.FullRequest{
-		Info: mailjetRequest,
-		Payload: resources.Sender{
-			Name:            sender.Name,
-			EmailType:       sender.EmailType,
-			IsDefaultSender: sender.IsDefaultSender,
-		},
+	r.updateStateWithFetchedSenderInformation(ctx, client, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return r.client.Put(mailjetFullRequest, []string{"Name", "EmailType", "IsDefaultSender"})
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *senderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.providerData.checkWritable(&resp.Diagnostics, "delete the Mailjet sender") {
+		return
+	}
+
 	var state senderResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -293,28 +372,71 @@ func (r *senderResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	mailjetRequest := &mailjet.Request{
-		Resource: "sender",
-		ID:       state.ID.ValueInt64(),
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	err := r.client.Delete(mailjetRequest)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	if err != nil {
+	err := r.providerData.clientFor(state.Credentials).DeleteSender(ctx, state.ID.ValueInt64())
+
+	// The sender might already have been deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting a Mailjet sender",
-			"Could not the sender, unexpected error: "+err.Error(),
+			"Could not delete the sender, unexpected error: "+err.Error(),
 		)
 		return
 	}
 }
 
+// ImportState looks up senders with the API keys of the provider since the credentials block is not available during an import
 func (r *senderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err == nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		return
+	}
 
-	if err != nil {
+	if !strings.Contains(req.ID, "@") {
+		resp.Diagnostics.AddError(
+			"Error importing item",
+			"Could not import the Mailjet sender, the ID should be an integer or an email address (*@example.com for a whole domain), got: "+req.ID,
+		)
+		return
+	}
+
+	senders, err := r.providerData.client.FindSendersByEmail(ctx, req.ID)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error importing item",
+			"Could not search the Mailjet sender "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	matchingIDs := make([]string, 0, len(senders))
+	for _, sender := range senders {
+		if sender.Status != senderStatusDeleted {
+			id = sender.ID
+			matchingIDs = append(matchingIDs, "#"+strconv.FormatInt(sender.ID, 10))
+		}
+	}
+
+	if len(matchingIDs) == 0 {
+		resp.Diagnostics.AddError(
+			"Error importing item",
+			"Could not import the Mailjet sender, no sender matches "+req.ID,
+		)
+		return
+	}
+
+	if len(matchingIDs) > 1 {
 		resp.Diagnostics.AddError(
 			"Error importing item",
-			"Could not import the Mailjet sender, the ID should be an integer: "+err.Error(),
+			"Could not import the Mailjet sender, several senders match "+req.ID+" ("+strings.Join(matchingIDs, ", ")+"), import it with its numeric ID instead",
 		)
 		return
 	}
@@ -0,0 +1,418 @@
+package mailjet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeSenderAPI serves a single sender the way the Mailjet API does
+type fakeSenderAPI struct {
+	t           *testing.T
+	sender      map[string]any
+	putPayloads []map[string]any
+}
+
+func (f *fakeSenderAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v3/REST/sender/12" {
+		f.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			f.t.Errorf("unable to decode the PUT payload: %s", err)
+		}
+		f.putPayloads = append(f.putPayloads, payload)
+		for key, value := range payload {
+			f.sender[key] = value
+		}
+	default:
+		f.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"Count": 1, "Data": []any{f.sender}, "Total": 1})
+}
+
+func newTestSenderResource(t *testing.T, handler http.Handler) (*senderResource, schema.Schema) {
+	t.Helper()
+
+	r := &senderResource{providerData: &providerData{client: newTestAPIClient(t, handler.ServeHTTP, time.Minute)}}
+
+	var schemaResponse resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResponse)
+
+	return r, schemaResponse.Schema
+}
+
+func newTestSenderState(t *testing.T, s schema.Schema, model senderResourceModel) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{Schema: s}
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("unable to build the state: %v", diags)
+	}
+
+	return state
+}
+
+func newTestSenderPlan(t *testing.T, s schema.Schema, model senderResourceModel) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+
+	return plan
+}
+
+func newFakeSender() map[string]any {
+	return map[string]any{
+		"ID":              12,
+		"DNSID":           34,
+		"Email":           "sender@example.com",
+		"Name":            "Sender",
+		"EmailType":       "transactional",
+		"IsDefaultSender": false,
+		"Status":          "Active",
+		"CreatedAt":       "2024-01-02T15:04:05Z",
+		"Filename":        "a1b2c3.txt",
+	}
+}
+
+func newTestSenderModel() senderResourceModel {
+	return senderResourceModel{
+		Email:              types.StringValue("sender@example.com"),
+		Name:               types.StringValue("Sender"),
+		IsDefaultSender:    types.BoolValue(false),
+		EmailType:          types.StringValue("transactional"),
+		ID:                 types.Int64Value(12),
+		DNSID:              types.Int64Value(34),
+		CreatedAt:          types.StringValue("2024-01-02T15:04:05Z"),
+		Status:             types.StringValue("Active"),
+		IsDomainWildcard:   types.BoolValue(false),
+		Domain:             types.StringValue("example.com"),
+		ValidationFilename: types.StringValue("a1b2c3.txt"),
+		Timeouts:           nullTimeouts(context.Background()),
+	}
+}
+
+func TestSenderResourceRead(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		remoteChanges map[string]any
+		expectedState *senderResourceModel
+	}
+
+	drifted := newTestSenderModel()
+	drifted.Name = types.StringValue("Renamed in the Mailjet UI")
+	drifted.IsDefaultSender = types.BoolValue(true)
+	drifted.EmailType = types.StringValue("bulk")
+
+	wildcard := newTestSenderModel()
+	wildcard.Email = types.StringValue("*@example.com")
+	wildcard.IsDomainWildcard = types.BoolValue(true)
+	wildcard.Status = types.StringValue("Inactive")
+
+	unchanged := newTestSenderModel()
+
+	tests := map[string]testCase{
+		"unchanged": {
+			remoteChanges: map[string]any{},
+			expectedState: &unchanged,
+		},
+		"drift": {
+			remoteChanges: map[string]any{"Name": "Renamed in the Mailjet UI", "IsDefaultSender": true, "EmailType": "bulk"},
+			expectedState: &drifted,
+		},
+		"inactive_domain_wildcard": {
+			remoteChanges: map[string]any{"Email": "*@example.com", "Status": "Inactive"},
+			expectedState: &wildcard,
+		},
+		"deleted": {
+			remoteChanges: map[string]any{"Status": "Deleted"},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &fakeSenderAPI{t: t, sender: newFakeSender()}
+			for key, value := range test.remoteChanges {
+				api.sender[key] = value
+			}
+			r, s := newTestSenderResource(t, api)
+
+			req := resource.ReadRequest{State: newTestSenderState(t, s, newTestSenderModel())}
+			resp := resource.ReadResponse{State: req.State}
+			r.Read(context.Background(), req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if test.expectedState == nil {
+				if !resp.State.Raw.IsNull() {
+					t.Errorf("expected the sender to be removed from the state")
+				}
+				return
+			}
+
+			var state senderResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+			if diff := cmp.Diff(state, *test.expectedState); diff != "" {
+				t.Errorf("unexpected state difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSenderResourceUpdate(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeSenderAPI{t: t, sender: newFakeSender()}
+	r, s := newTestSenderResource(t, api)
+
+	planned := newTestSenderModel()
+	planned.Name = types.StringValue("New name")
+	planned.IsDefaultSender = types.BoolValue(true)
+	planned.EmailType = types.StringValue("bulk")
+
+	req := resource.UpdateRequest{
+		State: newTestSenderState(t, s, newTestSenderModel()),
+		Plan:  newTestSenderPlan(t, s, planned),
+	}
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: req.Plan.Raw}}
+	r.Update(context.Background(), req, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	expectedPayloads := []map[string]any{{"Name": "New name", "EmailType": "bulk", "IsDefaultSender": true}}
+	if diff := cmp.Diff(api.putPayloads, expectedPayloads); diff != "" {
+		t.Errorf("unexpected update payload difference: %s", diff)
+	}
+
+	var state senderResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	if diff := cmp.Diff(state, planned); diff != "" {
+		t.Errorf("unexpected state difference: %s", diff)
+	}
+}
+
+func TestSenderResourceReadOnly(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		operation func(t *testing.T, r *senderResource, s schema.Schema) diag.Diagnostics
+	}
+
+	tests := map[string]testCase{
+		"create": {
+			operation: func(t *testing.T, r *senderResource, s schema.Schema) diag.Diagnostics {
+				req := resource.CreateRequest{Plan: newTestSenderPlan(t, s, newTestSenderModel())}
+				resp := resource.CreateResponse{State: tfsdk.State{Schema: s}}
+				r.Create(context.Background(), req, &resp)
+				return resp.Diagnostics
+			},
+		},
+		"update": {
+			operation: func(t *testing.T, r *senderResource, s schema.Schema) diag.Diagnostics {
+				req := resource.UpdateRequest{
+					State: newTestSenderState(t, s, newTestSenderModel()),
+					Plan:  newTestSenderPlan(t, s, newTestSenderModel()),
+				}
+				resp := resource.UpdateResponse{State: req.State}
+				r.Update(context.Background(), req, &resp)
+				return resp.Diagnostics
+			},
+		},
+		"delete": {
+			operation: func(t *testing.T, r *senderResource, s schema.Schema) diag.Diagnostics {
+				req := resource.DeleteRequest{State: newTestSenderState(t, s, newTestSenderModel())}
+				resp := resource.DeleteResponse{State: req.State}
+				r.Delete(context.Background(), req, &resp)
+				return resp.Diagnostics
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r, s := newTestSenderResource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request in read-only mode: %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			r.providerData.readOnly = true
+
+			diags := test.operation(t, r, s)
+			if diff := cmp.Diff(diagnosticSummaries(diags), []string{"Mailjet provider in read-only mode"}); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSenderResourceImportState(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		importID       string
+		statusCode     int
+		senders        []map[string]any
+		expectedID     int64
+		expectedDetail string
+	}
+
+	tests := map[string]testCase{
+		"numeric_id": {
+			importID:   "12",
+			expectedID: 12,
+		},
+		"email": {
+			importID:   "sender@example.com",
+			senders:    []map[string]any{{"ID": 12, "Email": "sender@example.com", "Status": "Active"}},
+			expectedID: 12,
+		},
+		"domain_wildcard": {
+			importID:   "*@example.com",
+			senders:    []map[string]any{{"ID": 13, "Email": "*@example.com", "Status": "Inactive"}},
+			expectedID: 13,
+		},
+		"deleted_senders_ignored": {
+			importID: "sender@example.com",
+			senders: []map[string]any{
+				{"ID": 10, "Email": "sender@example.com", "Status": "Deleted"},
+				{"ID": 12, "Email": "sender@example.com", "Status": "Active"},
+			},
+			expectedID: 12,
+		},
+		"only_deleted_senders": {
+			importID:       "sender@example.com",
+			senders:        []map[string]any{{"ID": 10, "Email": "sender@example.com", "Status": "Deleted"}},
+			expectedDetail: "Could not import the Mailjet sender, no sender matches sender@example.com",
+		},
+		"not_found": {
+			importID:       "sender@example.com",
+			statusCode:     http.StatusNotFound,
+			expectedDetail: "Could not import the Mailjet sender, no sender matches sender@example.com",
+		},
+		"several_senders": {
+			importID: "sender@example.com",
+			senders: []map[string]any{
+				{"ID": 12, "Email": "sender@example.com", "Status": "Active"},
+				{"ID": 14, "Email": "sender@example.com", "Status": "Inactive"},
+			},
+			expectedDetail: "Could not import the Mailjet sender, several senders match sender@example.com (#12, #14), import it with its numeric ID instead",
+		},
+		"neither_id_nor_email": {
+			importID:       "sender.example.com",
+			expectedDetail: "Could not import the Mailjet sender, the ID should be an integer or an email address (*@example.com for a whole domain), got: sender.example.com",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r, s := newTestSenderResource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/v3/REST/sender/"+test.importID {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				if test.statusCode != 0 {
+					w.WriteHeader(test.statusCode)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{"Count": len(test.senders), "Data": test.senders, "Total": len(test.senders)})
+			}))
+
+			resp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+			r.ImportState(context.Background(), resource.ImportStateRequest{ID: test.importID}, &resp)
+
+			if test.expectedDetail != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Detail() != test.expectedDetail {
+					t.Errorf("expected the error %q, got: %v", test.expectedDetail, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var id types.Int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("id"), &id)...)
+			if id.ValueInt64() != test.expectedID {
+				t.Errorf("expected the sender #%d to be imported, got: %s", test.expectedID, id)
+			}
+		})
+	}
+}
+
+func TestSenderResourceDelete(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		statusCode        int
+		expectedSummaries []string
+	}
+
+	tests := map[string]testCase{
+		"deleted": {
+			statusCode: http.StatusNoContent,
+		},
+		"already_deleted": {
+			statusCode: http.StatusNotFound,
+		},
+		"server_error": {
+			statusCode:        http.StatusInternalServerError,
+			expectedSummaries: []string{"Error deleting a Mailjet sender"},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r, s := newTestSenderResource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/v3/REST/sender/12" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(test.statusCode)
+			}))
+
+			req := resource.DeleteRequest{State: newTestSenderState(t, s, newTestSenderModel())}
+			resp := resource.DeleteResponse{State: req.State}
+			r.Delete(context.Background(), req, &resp)
+
+			if diff := cmp.Diff(diagnosticSummaries(resp.Diagnostics), test.expectedSummaries); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package mailjet
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func (r *senderResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   senderResourceSchemaV0(),
+			StateUpgrader: upgradeSenderResourceStateV0,
+		},
+		1: {
+			// v1 only made some attributes optional, the stored state has the same shape than v0
+			PriorSchema:   senderResourceSchemaV0(),
+			StateUpgrader: upgradeSenderResourceStateV1,
+		},
+	}
+}
+
+// senderResourceSchemaV0 is the schema used when name, is_default_sender and email_type were required
+// and created_at was formatted with RFC 850
+func senderResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"email":               schema.StringAttribute{Required: true},
+			"name":                schema.StringAttribute{Required: true},
+			"is_default_sender":   schema.BoolAttribute{Required: true},
+			"email_type":          schema.StringAttribute{Required: true},
+			"dns_id":              schema.Int64Attribute{Computed: true},
+			"id":                  schema.Int64Attribute{Computed: true},
+			"created_at":          schema.StringAttribute{Computed: true},
+			"status":              schema.StringAttribute{Computed: true},
+			"is_domain_wildcard":  schema.BoolAttribute{Computed: true},
+			"domain":              schema.StringAttribute{Computed: true},
+			"validation_filename": schema.StringAttribute{Computed: true},
+		},
+		Blocks: map[string]schema.Block{
+			"credentials": credentialsResourceBlock(),
+		},
+	}
+}
+
+// senderResourceModelV0 is the state stored with the schemas v0 and v1, before the timeouts block
+type senderResourceModelV0 struct {
+	Email              types.String `tfsdk:"email"`
+	Name               types.String `tfsdk:"name"`
+	IsDefaultSender    types.Bool   `tfsdk:"is_default_sender"`
+	EmailType          types.String `tfsdk:"email_type"`
+	ID                 types.Int64  `tfsdk:"id"`
+	DNSID              types.Int64  `tfsdk:"dns_id"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	Status             types.String `tfsdk:"status"`
+	IsDomainWildcard   types.Bool   `tfsdk:"is_domain_wildcard"`
+	Domain             types.String `tfsdk:"domain"`
+	ValidationFilename types.String `tfsdk:"validation_filename"`
+
+	Credentials *credentialsModel `tfsdk:"credentials"`
+}
+
+func (m senderResourceModelV0) toCurrentModel(ctx context.Context) senderResourceModel {
+	return senderResourceModel{
+		Email:              m.Email,
+		Name:               m.Name,
+		IsDefaultSender:    m.IsDefaultSender,
+		EmailType:          m.EmailType,
+		ID:                 m.ID,
+		DNSID:              m.DNSID,
+		CreatedAt:          upgradeRFC850Timestamp(m.CreatedAt),
+		Status:             m.Status,
+		IsDomainWildcard:   m.IsDomainWildcard,
+		Domain:             m.Domain,
+		ValidationFilename: m.ValidationFilename,
+		Credentials:        m.Credentials,
+		Timeouts:           nullTimeouts(ctx),
+	}
+}
+
+func upgradeSenderResourceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var priorState senderResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := priorState.toCurrentModel(ctx)
+	if state.Name.IsNull() {
+		state.Name = types.StringValue("")
+	}
+	if state.EmailType.IsNull() {
+		state.EmailType = types.StringValue("unknown")
+	}
+	if state.IsDefaultSender.IsNull() {
+		state.IsDefaultSender = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func upgradeSenderResourceStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var priorState senderResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, priorState.toCurrentModel(ctx))...)
+}
+
+// upgradeRFC850Timestamp converts a timestamp stored by the previous versions to RFC 3339.
+// Values that cannot be parsed are kept as is, they will be replaced by the next refresh.
+func upgradeRFC850Timestamp(value types.String) types.String {
+	if value.IsNull() || value.IsUnknown() {
+		return value
+	}
+
+	timestamp, err := time.Parse(time.RFC850, value.ValueString())
+	if err != nil {
+		return value
+	}
+
+	return types.StringValue(timestamp.Format(time.RFC3339))
+}
@@ -0,0 +1,105 @@
+package mailjet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSenderResourceUpgradeState(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		version       int64
+		priorState    senderResourceModelV0
+		expectedState senderResourceModel
+	}
+
+	priorState := senderResourceModelV0{
+		Email:              types.StringValue("sender@example.com"),
+		Name:               types.StringValue("Sender"),
+		IsDefaultSender:    types.BoolValue(false),
+		EmailType:          types.StringValue("transactional"),
+		ID:                 types.Int64Value(12),
+		DNSID:              types.Int64Value(34),
+		CreatedAt:          types.StringValue("Tuesday, 02-Jan-24 15:04:05 UTC"),
+		Status:             types.StringValue("Active"),
+		IsDomainWildcard:   types.BoolValue(false),
+		Domain:             types.StringValue("example.com"),
+		ValidationFilename: types.StringValue("a1b2c3.txt"),
+	}
+
+	withoutOptionalValues := priorState
+	withoutOptionalValues.Name = types.StringNull()
+	withoutOptionalValues.EmailType = types.StringNull()
+	withoutOptionalValues.IsDefaultSender = types.BoolNull()
+
+	withDefaultValues := newTestSenderModel()
+	withDefaultValues.Name = types.StringValue("")
+	withDefaultValues.EmailType = types.StringValue("unknown")
+	withDefaultValues.IsDefaultSender = types.BoolValue(false)
+
+	withUnexpectedTimestamp := priorState
+	withUnexpectedTimestamp.CreatedAt = types.StringValue("2 January 2024")
+
+	upgradedWithUnexpectedTimestamp := newTestSenderModel()
+	upgradedWithUnexpectedTimestamp.CreatedAt = types.StringValue("2 January 2024")
+
+	tests := map[string]testCase{
+		"v0": {
+			version:       0,
+			priorState:    priorState,
+			expectedState: newTestSenderModel(),
+		},
+		"v0_without_optional_values": {
+			version:       0,
+			priorState:    withoutOptionalValues,
+			expectedState: withDefaultValues,
+		},
+		"v1": {
+			version:       1,
+			priorState:    priorState,
+			expectedState: newTestSenderModel(),
+		},
+		"v1_unexpected_timestamp": {
+			version:       1,
+			priorState:    withUnexpectedTimestamp,
+			expectedState: upgradedWithUnexpectedTimestamp,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &senderResource{}
+			var schemaResponse resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResponse)
+
+			upgrader := r.UpgradeState(context.Background())[test.version]
+			priorState := tfsdk.State{Schema: *upgrader.PriorSchema}
+			if diags := priorState.Set(context.Background(), test.priorState); diags.HasError() {
+				t.Fatalf("unable to build the prior state: %v", diags)
+			}
+
+			req := resource.UpgradeStateRequest{State: &priorState}
+			resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResponse.Schema}}
+			upgrader.StateUpgrader(context.Background(), req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var state senderResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+			if diff := cmp.Diff(state, test.expectedState); diff != "" {
+				t.Errorf("unexpected state difference: %s", diff)
+			}
+		})
+	}
+}
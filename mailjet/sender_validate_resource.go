@@ -2,17 +2,23 @@ package mailjet
 
 import (
 	"context"
-	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mailjet/mailjet-apiv3-go/v3/resources"
-	"github.com/mailjet/mailjet-apiv3-go/v4"
 )
 
 var (
@@ -20,37 +26,31 @@ var (
 	_ resource.ResourceWithConfigure = &senderValidateResource{}
 )
 
+const (
+	defaultPollInterval      = time.Second
+	defaultMaxPollInterval   = 30 * time.Second
+	defaultPollBackoffFactor = 2.0
+	// maxLastAttemptMargin is the maximum time kept before the create timeout to make a last validation attempt
+	maxLastAttemptMargin = 5 * time.Second
+)
+
 func NewSenderValidateResource() resource.Resource {
 	return &senderValidateResource{}
 }
 
 type senderValidateResource struct {
-	client *mailjet.Client
+	providerData *providerData
 }
 
 func (r *senderValidateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
-	}
-
-	client, ok := req.ProviderData.(*mailjet.Client)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *mailjet.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-
-		return
-	}
-
-	r.client = client
+	r.providerData = configureProviderData(req.ProviderData, &resp.Diagnostics)
 }
 
 func (r *senderValidateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_sender_validate"
 }
 
-func (r *senderValidateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *senderValidateResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
@@ -60,23 +60,101 @@ func (r *senderValidateResource) Schema(_ context.Context, _ resource.SchemaRequ
 					int64planmodifier.RequiresReplace(),
 				},
 			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that force a new validation of the sender when they change, e.g. the values of the DNS entries of the domain. When set, the sender is validated even if it is already active.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
 			"wait_for": schema.StringAttribute{
+				Optional:           true,
+				Description:        "When specified, the provider will make multiple attempts to validate the resource until the specified duration is reached. The attempts are spaced according to poll_interval, max_poll_interval and poll_backoff_factor.",
+				DeprecationMessage: "Use the create attribute of the timeouts block instead, wait_for is only used when it is not set.",
+				Validators: []validator.String{
+					TimeDurationAtLeast1Sec(),
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: "Time to wait after the first failed validation attempt before trying again, e.g. \"5s\". Defaults to 1s.",
+				Validators: []validator.String{
+					TimeDurationAtLeast1Sec(),
+				},
+			},
+			"max_poll_interval": schema.StringAttribute{
 				Optional:    true,
-				Description: "When specified, the provider will make multiple attempts to validate the resource until the specified duration is reached. One attempt is made per second.",
+				Description: "Maximum time to wait between two validation attempts, e.g. \"1m\". Defaults to 30s.",
 				Validators: []validator.String{
 					TimeDurationAtLeast1Sec(),
 				},
 			},
+			"poll_backoff_factor": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Factor applied to the time to wait after each failed validation attempt, until max_poll_interval is reached. 1 keeps a constant interval. Defaults to 2.",
+				Validators: []validator.Float64{
+					Float64AtLeast(1),
+				},
+			},
+			"validation_method": schema.StringAttribute{
+				Computed:    true,
+				Description: "Method used by Mailjet to validate the sender. It is null when the sender was already active.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Status of the sender: Inactive or Active.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validated": schema.BoolAttribute{
+				Computed: true,
+				Description: "Indicates whether the sender is active. When a validated sender is not active anymore, e.g. because its DNS entries were removed, " +
+					"the resource is removed from the state so the next apply validates it again.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"credentials": credentialsResourceBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+				CreateDescription: "How long to keep trying to validate the sender, e.g. \"10m\". " +
+					"The last attempt is made shortly before the timeout is reached. " +
+					"When neither this nor wait_for is set a single validation attempt is made.",
+			}),
 		},
 	}
 }
 
 type senderValidateResourceModel struct {
-	ID      types.Int64  `tfsdk:"id"`
-	WaitFor types.String `tfsdk:"wait_for"`
+	ID                types.Int64   `tfsdk:"id"`
+	Triggers          types.Map     `tfsdk:"triggers"`
+	WaitFor           types.String  `tfsdk:"wait_for"`
+	PollInterval      types.String  `tfsdk:"poll_interval"`
+	MaxPollInterval   types.String  `tfsdk:"max_poll_interval"`
+	PollBackoffFactor types.Float64 `tfsdk:"poll_backoff_factor"`
+	ValidationMethod  types.String  `tfsdk:"validation_method"`
+	Status            types.String  `tfsdk:"status"`
+	Validated         types.Bool    `tfsdk:"validated"`
+
+	Credentials *credentialsModel `tfsdk:"credentials"`
+	Timeouts    timeouts.Value    `tfsdk:"timeouts"`
 }
 
 func (r *senderValidateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.providerData.checkWritable(&resp.Diagnostics, "validate the Mailjet sender") {
+		return
+	}
+
 	var state senderValidateResourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
@@ -84,11 +162,6 @@ func (r *senderValidateResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	senderSearchRequest := &mailjet.Request{
-		Resource: "sender",
-		ID:       state.ID.ValueInt64(),
-	}
-
 	durationString := state.WaitFor.ValueString()
 	if durationString == "" {
 		durationString = "0s"
@@ -103,41 +176,111 @@ func (r *senderValidateResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	var responseDataSearch []resources.Sender
-	err = r.client.Get(senderSearchRequest, &responseDataSearch)
+	pollInterval := defaultPollInterval
+	if !state.PollInterval.IsNull() {
+		pollInterval, err = time.ParseDuration(state.PollInterval.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_interval"),
+				"Failed to parse poll_interval",
+				err.Error(),
+			)
+			return
+		}
+	}
 
-	if err == nil && len(responseDataSearch) == 1 && responseDataSearch[0].Status == "Active" {
-		diags := resp.State.Set(ctx, state)
-		resp.Diagnostics.Append(diags...)
-		return
+	maxPollInterval := defaultMaxPollInterval
+	if !state.MaxPollInterval.IsNull() {
+		maxPollInterval, err = time.ParseDuration(state.MaxPollInterval.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_poll_interval"),
+				"Failed to parse max_poll_interval",
+				err.Error(),
+			)
+			return
+		}
 	}
 
-	mailjetValidateRequest := &mailjet.Request{
-		Resource: "sender",
-		ID:       state.ID.ValueInt64(),
-		Action:   "validate",
+	pollBackoffFactor := defaultPollBackoffFactor
+	if !state.PollBackoffFactor.IsNull() {
+		pollBackoffFactor = state.PollBackoffFactor.ValueFloat64()
 	}
 
-	mailjetValidateFullRequest := &mailjet.FullRequest{
-		Info: mailjetValidateRequest,
+	// The create timeout supersedes wait_for, without any of them a single attempt is made
+	createTimeout, diags := state.Timeouts.Create(ctx, waitForDuration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	retry := createTimeout > 0
+	if !retry {
+		createTimeout = defaultOperationTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	lastAttemptMargin := min(maxLastAttemptMargin, createTimeout/10)
+
+	client := r.providerData.clientFor(state.Credentials)
+
+	// An active sender is only validated again when triggers are set, e.g. to check the new values of its DNS entries
+	if state.Triggers.IsNull() {
+		responseDataSearch, err := client.GetSender(ctx, state.ID.ValueInt64())
+
+		if err == nil && len(responseDataSearch) == 1 && responseDataSearch[0].Status == senderStatusActive {
+			state.ValidationMethod = types.StringNull()
+			refreshSenderValidationStatus(&responseDataSearch[0], &state)
+			diags := resp.State.Set(ctx, state)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
 	}
 
-	var responseDataValidation []resources.SenderValidate
+	var validation *resources.SenderValidate
 
-	startAttempt := time.Now()
-	for {
-		err = r.client.Post(mailjetValidateFullRequest, responseDataValidation)
+	interval := min(pollInterval, maxPollInterval)
+	for attempt := 1; ; attempt++ {
+		validation, err = client.ValidateSender(ctx, state.ID.ValueInt64())
 
-		if err == nil && len(responseDataValidation) == 1 && responseDataValidation[0].GlobalError == "" {
-			return
+		if err == nil && validation.GlobalError == "" {
+			tflog.Info(ctx, "Mailjet sender validated", map[string]interface{}{
+				"id":                state.ID.ValueInt64(),
+				"attempt":           attempt,
+				"validation_method": validation.ValidationMethod,
+			})
+			break
 		}
-		if time.Since(startAttempt) > waitForDuration {
+		if !retry {
+			break
+		}
+
+		// The last sleep is shortened so a last attempt can still be made before the timeout
+		remaining := time.Until(deadline) - lastAttemptMargin
+		if remaining <= 0 {
 			break
 		}
-		time.Sleep(time.Second)
+		sleep := min(interval, remaining)
+
+		fields := map[string]interface{}{
+			"id":              state.ID.ValueInt64(),
+			"attempt":         attempt,
+			"next_attempt_in": sleep.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["error"] = senderValidationErrorMessage(validation)
+		}
+		tflog.Info(ctx, "Mailjet sender not validated yet", fields)
+
+		// The errors of the last attempt are reported below when the timeout is reached
+		if !sleepContext(ctx, sleep) {
+			break
+		}
+		interval = nextPollInterval(interval, pollBackoffFactor, maxPollInterval)
 	}
 
-	err = r.client.Post(mailjetValidateFullRequest, responseDataValidation)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error validating the Mailjet sender",
@@ -146,42 +289,136 @@ func (r *senderValidateResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	if len(responseDataValidation) == 0 {
+	if validation.GlobalError != "" {
 		resp.Diagnostics.AddError(
 			"Error validating the Mailjet sender",
-			"No validation methods were found for this sender.",
+			"Could not validate the sender: "+senderValidationErrorMessage(validation),
 		)
 		return
 	}
 
-	if len(responseDataValidation) > 1 {
+	state.ValidationMethod = types.StringValue(validation.ValidationMethod)
+
+	// Depending on the validation method the sender might only be activated later, e.g. with an activation email
+	sender, err := client.FindSender(ctx, state.ID.ValueInt64())
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error validating the Mailjet sender",
-			"Multiple data validation information where provided unexpectedly",
+			"Unable to read Mailjet sender information",
+			"Could not read sender #"+strconv.FormatInt(state.ID.ValueInt64(), 10)+" after its validation: "+err.Error(),
 		)
 		return
 	}
+	if sender == nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Mailjet sender information",
+			"Could not read sender #"+strconv.FormatInt(state.ID.ValueInt64(), 10)+" after its validation: the sender does not exist",
+		)
+		return
+	}
+	refreshSenderValidationStatus(sender, &state)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *senderValidateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state senderValidateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if responseDataValidation[0].GlobalError != "" {
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	sender, err := r.providerData.clientFor(state.Credentials).FindSender(ctx, state.ID.ValueInt64())
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error validating the Mailjet sender",
-			"Could not validate the sender: "+responseDataValidation[0].GlobalError,
+			"Unable to read Mailjet sender information",
+			"Could not read sender #"+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
 		)
 		return
 	}
 
-	diags := resp.State.Set(ctx, state)
+	// A sender waiting for its activation is kept, one that is not active anymore needs to be validated again
+	if sender == nil || sender.Status == senderStatusDeleted || (state.Validated.ValueBool() && sender.Status != senderStatusActive) {
+		tflog.Warn(ctx, "Mailjet sender not validated anymore, removing its validation from the state", map[string]interface{}{
+			"id": state.ID.ValueInt64(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	refreshSenderValidationStatus(sender, &state)
+
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r *senderValidateResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
-	// No need to do anything, the resource does not really exist on the Mailjet side
+func refreshSenderValidationStatus(sender *resources.Sender, state *senderValidateResourceModel) {
+	state.Status = types.StringValue(sender.Status)
+	state.Validated = types.BoolValue(sender.Status == senderStatusActive)
 }
 
-func (r *senderValidateResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
-	// No need to do anything
+func (r *senderValidateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan senderValidateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The updatable attributes only tune how the validation is done, there is nothing to change on the Mailjet side
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *senderValidateResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
 	// No need to do anything
 }
+
+// nextPollInterval increases the time to wait between two validation attempts up to maxInterval
+func nextPollInterval(interval time.Duration, factor float64, maxInterval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * factor)
+	if next <= 0 || next > maxInterval {
+		return maxInterval
+	}
+
+	return next
+}
+
+// sleepContext waits for the given duration, it returns false if the context is done before
+func sleepContext(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// senderValidationErrorMessage lists the error of each validation method after the global one
+func senderValidationErrorMessage(validation *resources.SenderValidate) string {
+	message := validation.GlobalError
+
+	methods := make([]string, 0, len(validation.Errors))
+	for method := range validation.Errors {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+
+	for _, method := range methods {
+		message += "\n  - " + method + ": " + validation.Errors[method]
+	}
+
+	return message
+}
@@ -0,0 +1,556 @@
+package mailjet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mailjet/mailjet-apiv3-go/v3/resources"
+)
+
+func TestNextPollInterval(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		interval         time.Duration
+		factor           float64
+		maxInterval      time.Duration
+		expectedInterval time.Duration
+	}
+
+	tests := map[string]testCase{
+		"constant": {
+			interval:         time.Second,
+			factor:           1,
+			maxInterval:      30 * time.Second,
+			expectedInterval: time.Second,
+		},
+		"increase": {
+			interval:         2 * time.Second,
+			factor:           1.5,
+			maxInterval:      30 * time.Second,
+			expectedInterval: 3 * time.Second,
+		},
+		"capped": {
+			interval:         20 * time.Second,
+			factor:           2,
+			maxInterval:      30 * time.Second,
+			expectedInterval: 30 * time.Second,
+		},
+		"overflow": {
+			interval:         time.Duration(1 << 62),
+			factor:           4,
+			maxInterval:      30 * time.Second,
+			expectedInterval: 30 * time.Second,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			interval := nextPollInterval(test.interval, test.factor, test.maxInterval)
+
+			if diff := cmp.Diff(interval, test.expectedInterval); diff != "" {
+				t.Errorf("unexpected interval difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSleepContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if sleepContext(ctx, time.Minute) {
+		t.Error("expected the sleep to be interrupted by the context")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("the sleep was not interrupted in time, it lasted %s", elapsed)
+	}
+}
+
+func TestSenderValidationErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	message := senderValidationErrorMessage(&resources.SenderValidate{
+		GlobalError: "Validation failed",
+		Errors: map[string]string{
+			"ValidateByFile": "File not found",
+			"DNSValidation":  "No TXT record found",
+		},
+	})
+
+	expectedMessage := "Validation failed\n  - DNSValidation: No TXT record found\n  - ValidateByFile: File not found"
+	if diff := cmp.Diff(message, expectedMessage); diff != "" {
+		t.Errorf("unexpected message difference: %s", diff)
+	}
+}
+
+func TestSenderValidateResourceRead(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		remoteStatus      string
+		validated         bool
+		expectedStatus    string
+		expectedValidated bool
+		expectedRemoved   bool
+	}
+
+	tests := map[string]testCase{
+		"active": {
+			remoteStatus:      "Active",
+			validated:         true,
+			expectedStatus:    "Active",
+			expectedValidated: true,
+		},
+		"waiting_for_activation": {
+			remoteStatus:   "Inactive",
+			expectedStatus: "Inactive",
+		},
+		"activated_since_last_read": {
+			remoteStatus:      "Active",
+			expectedStatus:    "Active",
+			expectedValidated: true,
+		},
+		"not_active_anymore": {
+			remoteStatus:    "Inactive",
+			validated:       true,
+			expectedRemoved: true,
+		},
+		"deleted": {
+			remoteStatus:    "Deleted",
+			expectedRemoved: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &fakeSenderAPI{t: t, sender: newFakeSender()}
+			api.sender["Status"] = test.remoteStatus
+			r := &senderValidateResource{providerData: &providerData{client: newTestAPIClient(t, api.ServeHTTP, time.Minute)}}
+
+			var schemaResponse resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResponse)
+
+			priorState := senderValidateResourceModel{
+				ID:                types.Int64Value(12),
+				Triggers:          types.MapNull(types.StringType),
+				WaitFor:           types.StringNull(),
+				PollInterval:      types.StringNull(),
+				MaxPollInterval:   types.StringNull(),
+				PollBackoffFactor: types.Float64Null(),
+				ValidationMethod:  types.StringValue("DNS"),
+				Status:            types.StringValue("Active"),
+				Validated:         types.BoolValue(test.validated),
+				Timeouts:          nullTimeouts(context.Background()),
+			}
+			req := resource.ReadRequest{State: tfsdk.State{Schema: schemaResponse.Schema}}
+			if diags := req.State.Set(context.Background(), priorState); diags.HasError() {
+				t.Fatalf("unable to build the state: %v", diags)
+			}
+			resp := resource.ReadResponse{State: req.State}
+			r.Read(context.Background(), req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if diff := cmp.Diff(resp.State.Raw.IsNull(), test.expectedRemoved); diff != "" {
+				t.Fatalf("unexpected removal difference: %s", diff)
+			}
+			if test.expectedRemoved {
+				return
+			}
+
+			expectedState := priorState
+			expectedState.Status = types.StringValue(test.expectedStatus)
+			expectedState.Validated = types.BoolValue(test.expectedValidated)
+
+			var state senderValidateResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+			if diff := cmp.Diff(state, expectedState); diff != "" {
+				t.Errorf("unexpected state difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSenderValidateResourceUpdate(t *testing.T) {
+	t.Parallel()
+
+	r := &senderValidateResource{}
+	var schemaResponse resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResponse)
+
+	planned := senderValidateResourceModel{
+		ID:                types.Int64Value(12),
+		Triggers:          types.MapValueMust(types.StringType, map[string]attr.Value{"dkim": types.StringValue("k=rsa; p=ABC")}),
+		WaitFor:           types.StringValue("10m"),
+		PollInterval:      types.StringValue("5s"),
+		MaxPollInterval:   types.StringValue("1m"),
+		PollBackoffFactor: types.Float64Value(1.5),
+		ValidationMethod:  types.StringValue("DNS"),
+		Status:            types.StringValue("Active"),
+		Validated:         types.BoolValue(true),
+		Timeouts:          nullTimeouts(context.Background()),
+	}
+	req := resource.UpdateRequest{Plan: tfsdk.Plan{Schema: schemaResponse.Schema}}
+	if diags := req.Plan.Set(context.Background(), planned); diags.HasError() {
+		t.Fatalf("unable to build the plan: %v", diags)
+	}
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResponse.Schema}}
+	r.Update(context.Background(), req, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state senderValidateResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	if diff := cmp.Diff(state, planned); diff != "" {
+		t.Errorf("unexpected state difference: %s", diff)
+	}
+}
+
+func TestSenderValidateResourceCreateReadOnly(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in read-only mode: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}, time.Minute)
+	r := &senderValidateResource{providerData: &providerData{client: client, readOnly: true}}
+
+	var schemaResponse resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResponse)
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResponse.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{Config: tfsdk.Config{Schema: schemaResponse.Schema}}, &resp)
+
+	if diff := cmp.Diff(diagnosticSummaries(resp.Diagnostics), []string{"Mailjet provider in read-only mode"}); diff != "" {
+		t.Errorf("unexpected diagnostics difference: %s", diff)
+	}
+}
+
+// fakeSenderValidateAPI serves the sender #12 and its validation, the validation responses are given by attempt and the last one is repeated
+type fakeSenderValidateAPI struct {
+	t           *testing.T
+	mu          sync.Mutex
+	status      string
+	validations []map[string]any
+	attempts    []time.Time
+}
+
+func (f *fakeSenderValidateAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var response any
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/REST/sender/12":
+		sender := newFakeSender()
+		sender["Status"] = f.status
+		response = map[string]any{"Count": 1, "Data": []any{sender}, "Total": 1}
+	case r.Method == http.MethodPost && r.URL.Path == "/v3/REST/sender/12/validate":
+		validation := f.validations[min(len(f.attempts), len(f.validations)-1)]
+		f.attempts = append(f.attempts, time.Now())
+		// Unlike an activation email, a DNS validation activates the sender right away
+		if validation["ValidationMethod"] == "DNS" {
+			f.status = "Active"
+		}
+		response = map[string]any{"Count": 1, "Data": []any{validation}, "Total": 1}
+	default:
+		f.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (f *fakeSenderValidateAPI) attemptTimes() []time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.attempts)
+}
+
+func newTestSenderValidateConfig() senderValidateResourceModel {
+	return senderValidateResourceModel{
+		ID:                types.Int64Value(12),
+		Triggers:          types.MapNull(types.StringType),
+		WaitFor:           types.StringNull(),
+		PollInterval:      types.StringNull(),
+		MaxPollInterval:   types.StringNull(),
+		PollBackoffFactor: types.Float64Null(),
+		ValidationMethod:  types.StringNull(),
+		Status:            types.StringNull(),
+		Validated:         types.BoolNull(),
+		Timeouts:          nullTimeouts(context.Background()),
+	}
+}
+
+func createTestSenderValidate(ctx context.Context, t *testing.T, api http.Handler, config senderValidateResourceModel) resource.CreateResponse {
+	t.Helper()
+
+	r := &senderValidateResource{providerData: &providerData{client: newTestAPIClient(t, api.ServeHTTP, time.Minute)}}
+
+	var schemaResponse resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResponse)
+
+	configState := tfsdk.State{Schema: schemaResponse.Schema}
+	if diags := configState.Set(context.Background(), config); diags.HasError() {
+		t.Fatalf("unable to build the configuration: %v", diags)
+	}
+
+	req := resource.CreateRequest{Config: tfsdk.Config{Schema: schemaResponse.Schema, Raw: configState.Raw}}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResponse.Schema}}
+	r.Create(ctx, req, &resp)
+
+	return resp
+}
+
+func TestSenderValidateResourceCreatePolling(t *testing.T) {
+	t.Parallel()
+
+	failedValidation := map[string]any{
+		"Errors":           map[string]string{"DNSValidationError": "No TXT record found"},
+		"ValidationMethod": "",
+		"GlobalError":      "Validation failed",
+	}
+	successfulValidation := map[string]any{"Errors": map[string]string{}, "ValidationMethod": "DNS", "GlobalError": ""}
+
+	type testCase struct {
+		waitFor     string
+		validations []map[string]any
+		// expectedMinGaps are the minimal durations between the first attempts
+		expectedMinGaps []time.Duration
+		// expectedMinLastAttempt is the minimal duration between the start of the creation and the last attempt
+		expectedMinLastAttempt time.Duration
+		expectedMinAttempts    int
+		expectedMaxAttempts    int
+		expectedError          bool
+	}
+
+	tests := map[string]testCase{
+		"single_attempt_without_timeout": {
+			validations:         []map[string]any{failedValidation, successfulValidation},
+			expectedMinAttempts: 1,
+			expectedMaxAttempts: 1,
+			expectedError:       true,
+		},
+		"validated_after_retries": {
+			waitFor:             "1m",
+			validations:         []map[string]any{failedValidation, failedValidation, successfulValidation},
+			expectedMinGaps:     []time.Duration{50 * time.Millisecond, 100 * time.Millisecond},
+			expectedMinAttempts: 3,
+			expectedMaxAttempts: 3,
+		},
+		"last_attempt_before_deadline": {
+			// 10% of the timeout is kept for the last attempt: attempts at 0, 50ms, 150ms, 350ms and 360ms
+			waitFor:                "400ms",
+			validations:            []map[string]any{failedValidation},
+			expectedMinGaps:        []time.Duration{50 * time.Millisecond, 100 * time.Millisecond},
+			expectedMinLastAttempt: 360 * time.Millisecond,
+			expectedMinAttempts:    3,
+			expectedMaxAttempts:    5,
+			expectedError:          true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &fakeSenderValidateAPI{t: t, status: "Inactive", validations: test.validations}
+
+			config := newTestSenderValidateConfig()
+			if test.waitFor != "" {
+				config.WaitFor = types.StringValue(test.waitFor)
+			}
+			config.PollInterval = types.StringValue("50ms")
+			config.MaxPollInterval = types.StringValue("1s")
+			config.PollBackoffFactor = types.Float64Value(2)
+
+			start := time.Now()
+			resp := createTestSenderValidate(context.Background(), t, api, config)
+
+			if diff := cmp.Diff(resp.Diagnostics.HasError(), test.expectedError); diff != "" {
+				t.Errorf("unexpected error difference: %s (%v)", diff, resp.Diagnostics)
+			}
+
+			attempts := api.attemptTimes()
+			if len(attempts) < test.expectedMinAttempts || len(attempts) > test.expectedMaxAttempts {
+				t.Fatalf("expected between %d and %d attempts, got %d", test.expectedMinAttempts, test.expectedMaxAttempts, len(attempts))
+			}
+			for i, minGap := range test.expectedMinGaps {
+				if gap := attempts[i+1].Sub(attempts[i]); gap < minGap {
+					t.Errorf("expected at least %s between attempts %d and %d, got %s", minGap, i+1, i+2, gap)
+				}
+			}
+			if lastAttempt := attempts[len(attempts)-1].Sub(start); lastAttempt < test.expectedMinLastAttempt {
+				t.Errorf("expected a last attempt after %s, got one after %s", test.expectedMinLastAttempt, lastAttempt)
+			}
+		})
+	}
+}
+
+func TestSenderValidateResourceCreateCancelled(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeSenderValidateAPI{
+		t:           t,
+		status:      "Inactive",
+		validations: []map[string]any{{"Errors": map[string]string{}, "ValidationMethod": "", "GlobalError": "Validation failed"}},
+	}
+
+	config := newTestSenderValidateConfig()
+	config.WaitFor = types.StringValue("1m")
+	config.PollInterval = types.StringValue("10s")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	resp := createTestSenderValidate(ctx, t, api, config)
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the polling to stop on cancellation, it lasted %s", elapsed)
+	}
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error when the validation is cancelled")
+	}
+	if attempts := len(api.attemptTimes()); attempts != 1 {
+		t.Errorf("expected a single attempt before the cancellation, got %d", attempts)
+	}
+}
+
+func TestSenderValidateResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		remoteStatus     string
+		triggers         types.Map
+		validation       map[string]any
+		expectedAttempts int
+		expectedDetail   string
+		expectedState    senderValidateResourceModel
+	}
+
+	validatedByDNS := newTestSenderValidateConfig()
+	validatedByDNS.ValidationMethod = types.StringValue("DNS")
+	validatedByDNS.Status = types.StringValue("Active")
+	validatedByDNS.Validated = types.BoolValue(true)
+
+	waitingForActivation := newTestSenderValidateConfig()
+	waitingForActivation.ValidationMethod = types.StringValue("ActivationEmail")
+	waitingForActivation.Status = types.StringValue("Inactive")
+	waitingForActivation.Validated = types.BoolValue(false)
+
+	alreadyActive := newTestSenderValidateConfig()
+	alreadyActive.Status = types.StringValue("Active")
+	alreadyActive.Validated = types.BoolValue(true)
+
+	triggers := types.MapValueMust(types.StringType, map[string]attr.Value{"dkim": types.StringValue("k=rsa; p=ABC")})
+	revalidated := validatedByDNS
+	revalidated.Triggers = triggers
+
+	tests := map[string]testCase{
+		"dns": {
+			remoteStatus:     "Inactive",
+			validation:       map[string]any{"Errors": map[string]string{}, "ValidationMethod": "DNS", "GlobalError": ""},
+			expectedAttempts: 1,
+			expectedState:    validatedByDNS,
+		},
+		"activation_email": {
+			remoteStatus:     "Inactive",
+			validation:       map[string]any{"Errors": map[string]string{}, "ValidationMethod": "ActivationEmail", "GlobalError": ""},
+			expectedAttempts: 1,
+			expectedState:    waitingForActivation,
+		},
+		"already_active": {
+			remoteStatus:  "Active",
+			triggers:      types.MapNull(types.StringType),
+			expectedState: alreadyActive,
+		},
+		"already_active_with_triggers": {
+			remoteStatus:     "Active",
+			triggers:         triggers,
+			validation:       map[string]any{"Errors": map[string]string{}, "ValidationMethod": "DNS", "GlobalError": ""},
+			expectedAttempts: 1,
+			expectedState:    revalidated,
+		},
+		"errors": {
+			remoteStatus: "Inactive",
+			validation: map[string]any{
+				"Errors": map[string]string{
+					"EmailValidationError": "No activation email sent",
+					"DNSValidationError":   "No TXT record found",
+				},
+				"ValidationMethod": "",
+				"GlobalError":      "Validation failed",
+			},
+			expectedAttempts: 1,
+			expectedDetail: "Could not validate the sender: Validation failed\n" +
+				"  - DNSValidationError: No TXT record found\n" +
+				"  - EmailValidationError: No activation email sent",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &fakeSenderValidateAPI{t: t, status: test.remoteStatus, validations: []map[string]any{test.validation}}
+			config := newTestSenderValidateConfig()
+			if !test.triggers.IsNull() {
+				config.Triggers = test.triggers
+			}
+			resp := createTestSenderValidate(context.Background(), t, api, config)
+
+			if attempts := len(api.attemptTimes()); attempts != test.expectedAttempts {
+				t.Errorf("expected %d validation attempts, got %d", test.expectedAttempts, attempts)
+			}
+
+			if test.expectedDetail != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Detail() != test.expectedDetail {
+					t.Errorf("expected the error %q, got: %v", test.expectedDetail, resp.Diagnostics)
+				}
+				if !resp.State.Raw.IsNull() {
+					t.Errorf("expected no state to be stored when the validation fails")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var state senderValidateResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+			if diff := cmp.Diff(state, test.expectedState); diff != "" {
+				t.Errorf("unexpected state difference: %s", diff)
+			}
+		})
+	}
+}
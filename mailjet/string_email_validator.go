@@ -0,0 +1,47 @@
+package mailjet
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = emailAddressValidator{}
+
+type emailAddressValidator struct {
+}
+
+func (validator emailAddressValidator) Description(_ context.Context) string {
+	return `must be an email address (e.g. sender@example.com) or a domain wildcard (e.g. *@example.com)`
+}
+
+func (validator emailAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return validator.Description(ctx)
+}
+
+func (validator emailAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	s := req.ConfigValue
+
+	if s.IsUnknown() || s.IsNull() {
+		return
+	}
+
+	// *@example.com is also a valid RFC 5322 address, the wildcard is a regular character of the local part
+	address, err := mail.ParseAddress(s.ValueString())
+
+	if err != nil || address.Name != "" || address.Address != s.ValueString() {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"invalid email address",
+			fmt.Sprintf("%q %s", s.ValueString(), validator.Description(ctx))),
+		)
+		return
+	}
+}
+
+func EmailAddressOrDomainWildcard() validator.String {
+	return emailAddressValidator{}
+}
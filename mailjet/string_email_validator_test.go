@@ -0,0 +1,89 @@
+package mailjet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEmailAddressOrDomainWildcard(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		val                 types.String
+		expectedDiagnostics diag.Diagnostics
+	}
+
+	invalidDiagnostics := func(value string) diag.Diagnostics {
+		return diag.Diagnostics{
+			diag.NewAttributeErrorDiagnostic(
+				path.Root("test"),
+				"invalid email address",
+				`"`+value+`" must be an email address (e.g. sender@example.com) or a domain wildcard (e.g. *@example.com)`,
+			),
+		}
+	}
+
+	tests := map[string]testCase{
+		"unknown": {
+			val: types.StringUnknown(),
+		},
+		"null": {
+			val: types.StringNull(),
+		},
+		"email": {
+			val: types.StringValue("sender@example.com"),
+		},
+		"email_with_subaddress": {
+			val: types.StringValue("sender+news@mail.example.com"),
+		},
+		"domain_wildcard": {
+			val: types.StringValue("*@example.com"),
+		},
+		"empty": {
+			val:                 types.StringValue(""),
+			expectedDiagnostics: invalidDiagnostics(""),
+		},
+		"missing_domain": {
+			val:                 types.StringValue("sender@"),
+			expectedDiagnostics: invalidDiagnostics("sender@"),
+		},
+		"missing_at_sign": {
+			val:                 types.StringValue("example.com"),
+			expectedDiagnostics: invalidDiagnostics("example.com"),
+		},
+		"display_name": {
+			val:                 types.StringValue("Sender <sender@example.com>"),
+			expectedDiagnostics: invalidDiagnostics("Sender <sender@example.com>"),
+		},
+		"surrounding_spaces": {
+			val:                 types.StringValue(" sender@example.com"),
+			expectedDiagnostics: invalidDiagnostics(" sender@example.com"),
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			request := validator.StringRequest{
+				Path:           path.Root("test"),
+				PathExpression: path.MatchRoot("test"),
+				ConfigValue:    test.val,
+			}
+
+			response := validator.StringResponse{}
+
+			EmailAddressOrDomainWildcard().ValidateString(context.Background(), request, &response)
+
+			if diff := cmp.Diff(response.Diagnostics, test.expectedDiagnostics); diff != "" {
+				t.Errorf("unexpected diagnostics difference: %s", diff)
+			}
+		})
+	}
+}
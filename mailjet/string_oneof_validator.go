@@ -0,0 +1,51 @@
+package mailjet
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = stringOneOfValidator{}
+
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (validator stringOneOfValidator) Description(_ context.Context) string {
+	quotedValues := make([]string, 0, len(validator.values))
+	for _, value := range validator.values {
+		quotedValues = append(quotedValues, fmt.Sprintf("%q", value))
+	}
+
+	return `must be one of: ` + strings.Join(quotedValues, ", ")
+}
+
+func (validator stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return validator.Description(ctx)
+}
+
+func (validator stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	s := req.ConfigValue
+
+	if s.IsUnknown() || s.IsNull() {
+		return
+	}
+
+	if !slices.Contains(validator.values, s.ValueString()) {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"invalid value",
+			fmt.Sprintf("%q %s", s.ValueString(), validator.Description(ctx))),
+		)
+		return
+	}
+}
+
+func StringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
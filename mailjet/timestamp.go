@@ -0,0 +1,17 @@
+package mailjet
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mailjet/mailjet-apiv3-go/v3/resources"
+)
+
+// timestampValue formats the timestamps returned by the Mailjet API, every timestamp attribute uses RFC 3339
+func timestampValue(timestamp *resources.RFC3339DateTime) types.String {
+	if timestamp == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(timestamp.Format(time.RFC3339))
+}
@@ -0,0 +1,44 @@
+package mailjet
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var _ http.RoundTripper = &userAgentTransport{}
+
+// userAgentTransport identifies the provider in the User-Agent header of every request sent to the Mailjet API
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func newUserAgentTransport(next http.RoundTripper, userAgent string) *userAgentTransport {
+	return &userAgentTransport{
+		next:      next,
+		userAgent: userAgent,
+	}
+}
+
+// buildUserAgent omits the Terraform version when it is not known, e.g. when the provider is not run by Terraform
+func buildUserAgent(providerVersion string, terraformVersion string, suffix string) string {
+	userAgent := "terraform-provider-mailjet/" + providerVersion
+	if terraformVersion != "" {
+		userAgent += fmt.Sprintf(" (+terraform %s)", terraformVersion)
+	}
+
+	suffix = strings.TrimSpace(suffix)
+	if suffix != "" {
+		userAgent += " " + suffix
+	}
+
+	return userAgent
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	userAgentReq := req.Clone(req.Context())
+	userAgentReq.Header.Set("User-Agent", t.userAgent)
+
+	return t.next.RoundTrip(userAgentReq)
+}
@@ -0,0 +1,88 @@
+package mailjet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBuildUserAgent(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		terraformVersion  string
+		suffix            string
+		expectedUserAgent string
+	}
+
+	tests := map[string]testCase{
+		"default": {
+			terraformVersion:  "1.8.0",
+			expectedUserAgent: "terraform-provider-mailjet/1.2.3 (+terraform 1.8.0)",
+		},
+		"suffix": {
+			terraformVersion:  "1.8.0",
+			suffix:            "ci-pipeline/42",
+			expectedUserAgent: "terraform-provider-mailjet/1.2.3 (+terraform 1.8.0) ci-pipeline/42",
+		},
+		"suffix_trimmed": {
+			terraformVersion:  "1.8.0",
+			suffix:            "  ci-pipeline/42\n",
+			expectedUserAgent: "terraform-provider-mailjet/1.2.3 (+terraform 1.8.0) ci-pipeline/42",
+		},
+		"blank_suffix": {
+			terraformVersion:  "1.8.0",
+			suffix:            " \t",
+			expectedUserAgent: "terraform-provider-mailjet/1.2.3 (+terraform 1.8.0)",
+		},
+		"unknown_terraform_version": {
+			expectedUserAgent: "terraform-provider-mailjet/1.2.3",
+		},
+		"unknown_terraform_version_suffix": {
+			suffix:            "ci-pipeline/42",
+			expectedUserAgent: "terraform-provider-mailjet/1.2.3 ci-pipeline/42",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			userAgent := buildUserAgent("1.2.3", test.terraformVersion, test.suffix)
+			if userAgent != test.expectedUserAgent {
+				t.Errorf("expected %q, got %q", test.expectedUserAgent, userAgent)
+			}
+		})
+	}
+}
+
+func TestUserAgentTransport(t *testing.T) {
+	t.Parallel()
+
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("User-Agent", "mailjet-api-v3-go")
+
+	response, err := newUserAgentTransport(http.DefaultTransport, "terraform-provider-mailjet/1.2.3").RoundTrip(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	if userAgent != "terraform-provider-mailjet/1.2.3" {
+		t.Errorf("unexpected User-Agent header: %q", userAgent)
+	}
+	if request.Header.Get("User-Agent") != "mailjet-api-v3-go" {
+		t.Errorf("the original request should not be modified")
+	}
+}